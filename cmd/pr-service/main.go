@@ -7,15 +7,20 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/freeholder/pr-reviewer-service/internal/config"
 	"github.com/freeholder/pr-reviewer-service/internal/logging"
 	"github.com/freeholder/pr-reviewer-service/internal/migrate"
+	"github.com/freeholder/pr-reviewer-service/internal/notify"
+	"github.com/freeholder/pr-reviewer-service/internal/outbox"
 	"github.com/freeholder/pr-reviewer-service/internal/random"
 	"github.com/freeholder/pr-reviewer-service/internal/repository/postgres"
 	"github.com/freeholder/pr-reviewer-service/internal/service"
 	httptransport "github.com/freeholder/pr-reviewer-service/internal/transport/http"
+	"github.com/freeholder/pr-reviewer-service/internal/webhook"
 	_ "github.com/jackc/pgx/v5/stdlib"
 )
 
@@ -23,7 +28,8 @@ func main() {
 	cfg := config.MustLoad()
 	logger := logging.NewLogger()
 
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	db, err := sql.Open("pgx", cfg.DBDSN)
 	if err != nil {
@@ -50,20 +56,101 @@ func main() {
 	userRepo := postgres.NewUserRepo(db)
 	prRepo := postgres.NewPRRepo(db)
 	statsRepo := postgres.NewStatsRepo(db)
+	externalUserRepo := postgres.NewExternalUserRepo(db)
+	webhookRepo := postgres.NewWebhookRepo(db)
+	outboxRepo := postgres.NewOutboxRepo(db)
+	absenceRepo := postgres.NewAbsenceRepo(db)
+	backlogRepo := postgres.NewBacklogRepo(db)
 
 	teamSvc := service.NewTeamService(logger, teamRepo, userRepo)
 	userSvc := service.NewUserService(logger, userRepo, prRepo)
-	prSvc := service.NewPRService(logger, userRepo, prRepo, random.DefaultRandomizer{}, teamRepo)
+	prSvc := service.NewPRService(logger, userRepo, prRepo, random.DefaultRandomizer{}, teamRepo, backlogRepo)
 	statsSvc := service.NewStatsService(statsRepo)
+	webhookSvc := service.NewWebhookService(logger, webhookRepo, webhook.NewSender(cfg.WebhookTimeout))
+	integrationSvc := service.NewIntegrationService(logger, externalUserRepo, prSvc)
+	absenceSvc := service.NewAbsenceService(logger, absenceRepo, userRepo, prRepo, prSvc)
 
-	handler := httptransport.NewHandler(logger, teamSvc, userSvc, prSvc, statsSvc)
+	sinks := []service.OutboxSink{outbox.NewBacklogSink(userRepo, prSvc)}
+	for _, name := range cfg.OutboxSinks {
+		switch name {
+		case "webhook":
+			sinks = append(sinks, outbox.NewWebhookSink(webhookRepo))
+		case "log":
+			sinks = append(sinks, outbox.NewLogSink(logger))
+		case "slack":
+			slack := notify.NewSlackNotifier(teamRepo, cfg.SlackTimeout)
+			sinks = append(sinks, notify.NewEventSink(prRepo, userRepo, slack), notify.NewUnassignedEventSink(prRepo, userRepo, slack))
+		case "email":
+			email := mustEmailNotifier(cfg, logger)
+			sinks = append(sinks, notify.NewEventSink(prRepo, userRepo, email), notify.NewUnassignedEventSink(prRepo, userRepo, email))
+		default:
+			logger.Error("unknown outbox sink", slog.String("sink", name))
+			os.Exit(1)
+		}
+	}
+	dispatcher := service.NewOutboxDispatcher(logger, outboxRepo, sinks...)
+
+	var reminders notify.Multi
+	for _, name := range cfg.ReminderNotifiers {
+		switch name {
+		case "log":
+			reminders = append(reminders, notify.NewLogNotifier(logger))
+		case "slack":
+			reminders = append(reminders, notify.NewSlackNotifier(teamRepo, cfg.SlackTimeout))
+		case "email":
+			reminders = append(reminders, mustEmailNotifier(cfg, logger))
+		default:
+			logger.Error("unknown reminder notifier", slog.String("notifier", name))
+			os.Exit(1)
+		}
+	}
+	slaSvc := service.NewSLAService(logger, prRepo, userRepo, prSvc, reminders)
+
+	go dispatcher.Run(ctx, cfg.OutboxPollInterval, cfg.OutboxRetention)
+	go webhookSvc.Run(ctx, cfg.WebhookPollInterval)
+	go slaSvc.Run(ctx, cfg.SLAPollInterval)
+	go absenceSvc.Run(ctx, cfg.AbsencePollInterval)
+	go prSvc.RunBacklog(ctx, cfg.BacklogPollInterval)
+
+	handler := httptransport.NewHandler(logger, teamSvc, userSvc, prSvc, statsSvc, webhookSvc, integrationSvc, absenceSvc, cfg.GitHubWebhookSecret, cfg.GitLabWebhookToken)
 	router := httptransport.NewRouter(handler)
 
 	addr := ":" + cfg.HTTPPort
+	srv := &http.Server{Addr: addr, Handler: router}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Error("http server shutdown", "err", err)
+		}
+	}()
+
 	logger.Info("starting http server", slog.String("addr", addr))
 
-	if err := http.ListenAndServe(addr, router); err != nil && !errors.Is(err, http.ErrServerClosed) {
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		logger.Error("http server error", "err", err)
 		os.Exit(1)
 	}
 }
+
+func mustEmailNotifier(cfg config.Config, logger *slog.Logger) *notify.EmailNotifier {
+	if cfg.SMTPHost == "" || cfg.SMTPFrom == "" {
+		logger.Error("email notifications require APP_SMTP_HOST and APP_SMTP_FROM")
+		os.Exit(1)
+	}
+	n, err := notify.NewEmailNotifier(notify.SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.SMTPFrom,
+		Timeout:  cfg.SMTPTimeout,
+	})
+	if err != nil {
+		logger.Error("email notifier", slog.Any("err", err))
+		os.Exit(1)
+	}
+	return n
+}
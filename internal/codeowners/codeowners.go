@@ -0,0 +1,147 @@
+// Package codeowners parses CODEOWNERS files and matches paths against them.
+//
+// Patterns follow the gitignore subset supported by GitHub: "*" and "?"
+// match within a path segment, "**" matches across segments, a leading "/"
+// or a slash inside the pattern anchors it to the repository root, and a
+// trailing "/" matches everything under a directory. A wildcard in the last
+// segment does not reach into subdirectories: "docs/*" matches docs/a.md
+// but not docs/api/a.md. Negation ("!") and
+// character ranges ("[...]") are not supported. As in GitHub, the last
+// matching rule wins; a rule without owners makes paths unowned.
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type Rule struct {
+	Pattern string
+	Owners  []string
+	re      *regexp.Regexp
+}
+
+type Ruleset struct {
+	rules []Rule
+}
+
+// Parse reads a CODEOWNERS file. Errors mention the offending line.
+func Parse(content string) (Ruleset, error) {
+	var rs Ruleset
+
+	sc := bufio.NewScanner(strings.NewReader(content))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(stripComment(sc.Text()))
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		pattern, owners := fields[0], fields[1:]
+
+		re, err := compile(pattern)
+		if err != nil {
+			return Ruleset{}, fmt.Errorf("line %d: %w", n, err)
+		}
+		for _, o := range owners {
+			if !strings.Contains(o, "@") || o == "@" {
+				return Ruleset{}, fmt.Errorf("line %d: owner %q must be @user, @org/team or an email", n, o)
+			}
+		}
+
+		rs.rules = append(rs.rules, Rule{Pattern: pattern, Owners: owners, re: re})
+	}
+	if err := sc.Err(); err != nil {
+		return Ruleset{}, err
+	}
+
+	return rs, nil
+}
+
+func (rs Ruleset) Rules() []Rule {
+	return rs.rules
+}
+
+// Owners returns the owners of path, or nil if no rule owns it.
+func (rs Ruleset) Owners(path string) []string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "./"), "/")
+	for i := len(rs.rules) - 1; i >= 0; i-- {
+		if rs.rules[i].re.MatchString(path) {
+			return rs.rules[i].Owners
+		}
+	}
+	return nil
+}
+
+func stripComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func compile(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") {
+		return nil, fmt.Errorf("negated pattern %q is not supported", pattern)
+	}
+	if strings.ContainsAny(pattern, "[]") {
+		return nil, fmt.Errorf("character ranges in pattern %q are not supported", pattern)
+	}
+
+	dir := strings.HasSuffix(pattern, "/")
+	p := strings.TrimSuffix(pattern, "/")
+	anchored := strings.HasPrefix(p, "/") || strings.Contains(strings.TrimPrefix(p, "/"), "/")
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return nil, fmt.Errorf("pattern %q matches nothing", pattern)
+	}
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(p); {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 3
+		case p[i:] == "/**":
+			b.WriteString("/.*")
+			i += 3
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i += 2
+		case p[i] == '*':
+			b.WriteString("[^/]*")
+			i++
+		case p[i] == '?':
+			b.WriteString("[^/]")
+			i++
+		default:
+			b.WriteString(regexp.QuoteMeta(p[i : i+1]))
+			i++
+		}
+	}
+
+	// A pattern naming a file or directory also covers everything under it,
+	// but a wildcard in the last segment only matches at that level, so
+	// "docs/*" owns the direct children of docs only.
+	last := p[strings.LastIndex(p, "/")+1:]
+	switch {
+	case dir:
+		b.WriteString("/.*$")
+	case strings.ContainsAny(last, "*?"):
+		b.WriteString("$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,78 @@
+package codeowners
+
+import (
+	"slices"
+	"testing"
+)
+
+const sample = `
+# default owners
+*                 @org/backend
+
+*.js              @u-frontend   # inline comment
+/docs/            @u-docs
+apps/             @u-apps
+/build/logs/      @u-logs
+/guides/*         @u-guides
+internal/**/db    @u-db
+**/migrations     @u-db
+/scripts/         
+`
+
+func TestOwners(t *testing.T) {
+	rs, err := Parse(sample)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"main.go", []string{"@org/backend"}},
+		{"web/app.js", []string{"@u-frontend"}},
+		{"docs/readme.md", []string{"@u-docs"}},
+		{"/docs/api/index.md", []string{"@u-docs"}},
+		{"web/docs/readme.md", []string{"@org/backend"}},
+		{"apps/x/main.go", []string{"@u-apps"}},
+		{"web/apps/x/main.go", []string{"@u-apps"}},
+		{"build/logs/today.log", []string{"@u-logs"}},
+		{"guides/intro.md", []string{"@u-guides"}},
+		{"guides/api/intro.md", []string{"@org/backend"}},
+		{"guides/api/app.js", []string{"@u-frontend"}},
+		{"internal/db/conn.go", []string{"@u-db"}},
+		{"internal/repo/db/conn.go", []string{"@u-db"}},
+		{"migrations/001.sql", []string{"@u-db"}},
+		{"deploy/migrations/001.sql", []string{"@u-db"}},
+		{"scripts/run.sh", nil},
+	}
+
+	for _, tt := range tests {
+		if got := rs.Owners(tt.path); !slices.Equal(got, tt.want) {
+			t.Errorf("Owners(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, content := range []string{
+		"!vendor/ @u1",
+		"[abc].go @u1",
+		"*.go u1",
+		"/ @u1",
+	} {
+		if _, err := Parse(content); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", content)
+		}
+	}
+}
+
+func TestEmptyRuleset(t *testing.T) {
+	rs, err := Parse("# nothing here\n")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got := rs.Owners("main.go"); got != nil {
+		t.Errorf("Owners = %v, want nil", got)
+	}
+}
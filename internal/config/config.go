@@ -2,6 +2,7 @@ package config
 
 import (
 	"log"
+	"time"
 
 	"github.com/caarlos0/env/v10"
 	"github.com/joho/godotenv"
@@ -10,6 +11,31 @@ import (
 type Config struct {
 	HTTPPort string `env:"APP_HTTP_PORT" envDefault:"8080"`
 	DBDSN    string `env:"APP_DB_DSN,required"`
+
+	GitHubWebhookSecret string `env:"APP_GITHUB_WEBHOOK_SECRET"`
+	GitLabWebhookToken  string `env:"APP_GITLAB_WEBHOOK_TOKEN"`
+
+	WebhookPollInterval time.Duration `env:"APP_WEBHOOK_POLL_INTERVAL" envDefault:"5s"`
+	WebhookTimeout      time.Duration `env:"APP_WEBHOOK_TIMEOUT" envDefault:"10s"`
+
+	OutboxSinks        []string      `env:"APP_OUTBOX_SINKS" envDefault:"webhook"`
+	OutboxPollInterval time.Duration `env:"APP_OUTBOX_POLL_INTERVAL" envDefault:"1s"`
+	OutboxRetention    time.Duration `env:"APP_OUTBOX_RETENTION" envDefault:"168h"`
+
+	SlackTimeout time.Duration `env:"APP_SLACK_TIMEOUT" envDefault:"5s"`
+
+	SLAPollInterval   time.Duration `env:"APP_SLA_POLL_INTERVAL" envDefault:"1m"`
+	ReminderNotifiers []string      `env:"APP_REMINDER_NOTIFIERS" envDefault:"log"`
+
+	AbsencePollInterval time.Duration `env:"APP_ABSENCE_POLL_INTERVAL" envDefault:"1m"`
+	BacklogPollInterval time.Duration `env:"APP_BACKLOG_POLL_INTERVAL" envDefault:"1m"`
+
+	SMTPHost     string        `env:"APP_SMTP_HOST"`
+	SMTPPort     int           `env:"APP_SMTP_PORT" envDefault:"587"`
+	SMTPUsername string        `env:"APP_SMTP_USERNAME"`
+	SMTPPassword string        `env:"APP_SMTP_PASSWORD"`
+	SMTPFrom     string        `env:"APP_SMTP_FROM"`
+	SMTPTimeout  time.Duration `env:"APP_SMTP_TIMEOUT" envDefault:"10s"`
 }
 
 func MustLoad() Config {
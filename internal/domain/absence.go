@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+const maxAbsenceReasonLength = 200
+
+// Absence is a scheduled period when a user is away. While it lasts the user
+// is not picked as a reviewer, whatever their is_active flag says. With
+// ReassignReviews set, their open reviews are handed over once it starts.
+type Absence struct {
+	ID              int64
+	UserID          UserID
+	StartsAt        time.Time
+	EndsAt          time.Time
+	Reason          string
+	ReassignReviews bool
+	ReassignedAt    *time.Time
+	CreatedAt       time.Time
+}
+
+func (a Absence) Validate() error {
+	if a.UserID == "" {
+		return NewValidationError("user_id", "must not be empty")
+	}
+	if a.StartsAt.IsZero() {
+		return NewValidationError("starts_at", "must be set")
+	}
+	if a.EndsAt.IsZero() {
+		return NewValidationError("ends_at", "must be set")
+	}
+	if !a.EndsAt.After(a.StartsAt) {
+		return NewValidationError("ends_at", "must be after starts_at")
+	}
+	if len(a.Reason) > maxAbsenceReasonLength {
+		return NewValidationError("reason", "is too long")
+	}
+	return nil
+}
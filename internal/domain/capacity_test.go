@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReviewCapacity(t *testing.T) {
+	limit := func(n int) *int { return &n }
+	team := TeamSettings{MaxOpenReviews: 5}
+
+	tests := []struct {
+		name     string
+		user     User
+		settings TeamSettings
+		want     int
+	}{
+		{"inherits team default", User{}, team, 5},
+		{"own limit wins", User{MaxOpenReviews: limit(2)}, team, 2},
+		{"own limit above default", User{MaxOpenReviews: limit(8)}, team, 8},
+		{"exempt from team default", User{MaxOpenReviews: limit(0)}, team, 0},
+		{"no limits at all", User{}, TeamSettings{}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := ReviewCapacity(tt.user, tt.settings); got != tt.want {
+			t.Errorf("%s: ReviewCapacity = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidateReviewerCount(t *testing.T) {
+	settings := TeamSettings{MinReviewers: 2, MaxReviewers: 3}
+	reviewers := func(n int) []UserID {
+		ids := make([]UserID, n)
+		for i := range ids {
+			ids[i] = UserID(rune('a' + i))
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name       string
+		assigned   int
+		atCapacity int
+		wantErr    bool
+	}{
+		{"within bounds", 2, 0, false},
+		{"at maximum", 3, 0, false},
+		{"above maximum", 4, 0, true},
+		{"below minimum", 1, 0, true},
+		{"capacity fills the minimum", 1, 1, false},
+		{"nobody free, all at capacity", 0, 2, false},
+		{"capacity not enough", 0, 1, true},
+	}
+
+	for _, tt := range tests {
+		pr := PullRequest{AssignedReviewers: reviewers(tt.assigned)}
+		err := pr.ValidateReviewerCount(settings, tt.atCapacity)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: ValidateReviewerCount = %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		var ve *ValidationError
+		if err != nil && (!errors.As(err, &ve) || ve.Field != "assigned_reviewers") {
+			t.Errorf("%s: got %v, want a validation error on assigned_reviewers", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+const maxCodeownersLength = 64 * 1024
+
+type CodeownersMode string
+
+const (
+	// CodeownersModePrefer picks owners of the changed files when it can and
+	// fills the remaining slots with the team strategy.
+	CodeownersModePrefer CodeownersMode = "PREFER"
+	// CodeownersModeRequire refuses to create the PR unless every owned
+	// changed file gets one of its owners as a reviewer.
+	CodeownersModeRequire CodeownersMode = "REQUIRE"
+)
+
+func (m CodeownersMode) Validate() error {
+	switch m {
+	case CodeownersModePrefer, CodeownersModeRequire:
+		return nil
+	default:
+		return NewValidationError("mode", "must be PREFER or REQUIRE")
+	}
+}
+
+// Codeowners is the CODEOWNERS ruleset uploaded by a team. UpdatedAt is nil
+// while the team has none.
+type Codeowners struct {
+	TeamName  TeamName
+	Content   string
+	Mode      CodeownersMode
+	UpdatedAt *time.Time
+}
+
+func (c Codeowners) Validate() error {
+	if c.TeamName == "" {
+		return NewValidationError("team_name", "must not be empty")
+	}
+	if len(c.Content) > maxCodeownersLength {
+		return NewValidationError("content", "is too long")
+	}
+	return c.Mode.Validate()
+}
@@ -8,12 +8,17 @@ import (
 type ErrorCode string
 
 const (
-	ErrTeamExists  ErrorCode = "TEAM_EXISTS"
-	ErrPRExists    ErrorCode = "PR_EXISTS"
-	ErrPRMerged    ErrorCode = "PR_MERGED"
-	ErrNotAssigned ErrorCode = "NOT_ASSIGNED"
-	ErrNoCandidate ErrorCode = "NO_CANDIDATE"
-	ErrNotFound    ErrorCode = "NOT_FOUND"
+	ErrTeamExists   ErrorCode = "TEAM_EXISTS"
+	ErrPRExists     ErrorCode = "PR_EXISTS"
+	ErrPRMerged     ErrorCode = "PR_MERGED"
+	ErrNotAssigned  ErrorCode = "NOT_ASSIGNED"
+	ErrNoCandidate  ErrorCode = "NO_CANDIDATE"
+	ErrNotFound     ErrorCode = "NOT_FOUND"
+	ErrMergeBlocked ErrorCode = "MERGE_BLOCKED"
+	ErrPRClosed     ErrorCode = "PR_CLOSED"
+	ErrPRDraft      ErrorCode = "PR_DRAFT"
+	ErrUnauthorized ErrorCode = "UNAUTHORIZED"
+	ErrTooLarge     ErrorCode = "PAYLOAD_TOO_LARGE"
 )
 
 type DomainError struct {
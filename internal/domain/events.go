@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+// EventType names a domain event published through the outbox.
+type EventType string
+
+const (
+	EventPRCreated           EventType = "pr.created"
+	EventReviewerAssigned    EventType = "reviewer.assigned"
+	EventReviewerReplaced    EventType = "reviewer.replaced"
+	EventPRMerged            EventType = "pr.merged"
+	EventUserActivityChanged EventType = "user.activity_changed"
+)
+
+func (t EventType) Validate() error {
+	switch t {
+	case EventPRCreated, EventReviewerAssigned, EventReviewerReplaced, EventPRMerged, EventUserActivityChanged:
+		return nil
+	default:
+		return NewValidationError("event_types", "unknown event type "+string(t))
+	}
+}
+
+// EventForPREvent returns the domain event published for a PR event, if any.
+func EventForPREvent(t PREventType) (EventType, bool) {
+	switch t {
+	case PREventCreated:
+		return EventPRCreated, true
+	case PREventReviewerAssigned:
+		return EventReviewerAssigned, true
+	case PREventReviewerReplaced:
+		return EventReviewerReplaced, true
+	case PREventMerged:
+		return EventPRMerged, true
+	default:
+		return "", false
+	}
+}
+
+// OutboxEvent is a domain event stored in the same transaction as the change
+// that caused it. Payload is the JSON document handed to sinks;
+// DeliveredSinks names the sinks that already accepted it.
+type OutboxEvent struct {
+	ID             int64
+	Type           EventType
+	Payload        []byte
+	Attempts       int
+	DeliveredSinks []string
+	CreatedAt      time.Time
+}
+
+const (
+	MaxOutboxAttempts  = 20
+	outboxBackoffBase  = 5 * time.Second
+	outboxBackoffLimit = 10 * time.Minute
+)
+
+// OutboxBackoff returns the delay before an event whose sinks failed the
+// given number of times is dispatched again: 5s, 10s, 20s, ... capped at
+// ten minutes. After MaxOutboxAttempts the event is marked dead.
+func OutboxBackoff(attempts int) time.Duration {
+	d := outboxBackoffBase
+	for i := 1; i < attempts && d < outboxBackoffLimit; i++ {
+		d *= 2
+	}
+	return min(d, outboxBackoffLimit)
+}
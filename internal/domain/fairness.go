@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+const DefaultFairnessWindow = 30 * 24 * time.Hour
+
+type ActivityChange struct {
+	IsActive  bool
+	ChangedAt time.Time
+}
+
+// MemberActivity is a team member together with the history of their
+// is_active flag, ordered by ChangedAt.
+type MemberActivity struct {
+	UserID   UserID
+	Username string
+	TeamName TeamName
+	IsActive bool
+	Changes  []ActivityChange
+}
+
+// ActiveDuration returns how long the member was active within [from, to).
+func (m MemberActivity) ActiveDuration(from, to time.Time) time.Duration {
+	if len(m.Changes) == 0 {
+		if m.IsActive {
+			return to.Sub(from)
+		}
+		return 0
+	}
+
+	var total time.Duration
+	for i, c := range m.Changes {
+		if !c.IsActive {
+			continue
+		}
+		start, end := c.ChangedAt, to
+		if i+1 < len(m.Changes) {
+			end = m.Changes[i+1].ChangedAt
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if end.After(start) {
+			total += end.Sub(start)
+		}
+	}
+	return total
+}
+
+type FairnessFilter struct {
+	From     *time.Time
+	To       *time.Time
+	TeamName TeamName
+}
+
+func (f FairnessFilter) Validate() error {
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return NewValidationError("from", "must be before to")
+	}
+	return nil
+}
+
+type MemberFairness struct {
+	UserID        UserID
+	Username      string
+	ActiveDays    float64
+	AssignedCount int64
+	ExpectedShare float64
+	ActualShare   float64
+}
+
+// TeamFairness compares each member's share of assignments with the share
+// they would get if assignments were spread evenly over active days.
+// Gini and Spread are computed over assignments per active day.
+type TeamFairness struct {
+	TeamName         TeamName
+	TotalAssignments int64
+	Members          []MemberFairness
+	Gini             float64
+	Spread           float64
+}
+
+func NewTeamFairness(teamName TeamName, members []MemberActivity, counts map[UserID]int64, from, to time.Time) TeamFairness {
+	tf := TeamFairness{TeamName: teamName}
+
+	var totalDays float64
+	for _, m := range members {
+		days := m.ActiveDuration(from, to).Hours() / 24
+		if days <= 0 {
+			continue
+		}
+		tf.Members = append(tf.Members, MemberFairness{
+			UserID:        m.UserID,
+			Username:      m.Username,
+			ActiveDays:    days,
+			AssignedCount: counts[m.UserID],
+		})
+		totalDays += days
+		tf.TotalAssignments += counts[m.UserID]
+	}
+
+	rates := make([]float64, 0, len(tf.Members))
+	for i := range tf.Members {
+		m := &tf.Members[i]
+		m.ExpectedShare = m.ActiveDays / totalDays
+		if tf.TotalAssignments > 0 {
+			m.ActualShare = float64(m.AssignedCount) / float64(tf.TotalAssignments)
+		}
+		rates = append(rates, float64(m.AssignedCount)/m.ActiveDays)
+	}
+
+	tf.Gini = gini(rates)
+	tf.Spread = spread(rates)
+
+	return tf
+}
+
+// gini returns the Gini coefficient of values without reordering them.
+func gini(values []float64) float64 {
+	values = slices.Clone(values)
+	slices.Sort(values)
+
+	n := float64(len(values))
+	var sum, weighted float64
+	for i, v := range values {
+		sum += v
+		weighted += float64(i+1) * v
+	}
+	if sum == 0 {
+		return 0
+	}
+
+	g := (2*weighted)/(n*sum) - (n+1)/n
+	return math.Max(g, 0)
+}
+
+// spread returns max(values) - min(values), or 0 for no values.
+func spread(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	return slices.Max(values) - slices.Min(values)
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestGini(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"empty", nil, 0},
+		{"single", []float64{5}, 0},
+		{"all equal", []float64{2, 2, 2}, 0},
+		{"all zero", []float64{0, 0}, 0},
+		{"skewed", []float64{0, 4, 0, 0}, 0.75},
+		{"linear", []float64{3, 1, 2}, 2.0 / 9},
+	}
+
+	for _, tt := range tests {
+		in := slices.Clone(tt.values)
+		if got := gini(in); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: gini(%v) = %v, want %v", tt.name, tt.values, got, tt.want)
+		}
+		if !slices.Equal(in, tt.values) {
+			t.Errorf("%s: gini reordered its input to %v", tt.name, in)
+		}
+	}
+}
+
+func TestNewTeamFairnessSpread(t *testing.T) {
+	from := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(2 * 24 * time.Hour)
+	members := []MemberActivity{
+		{UserID: "u1", IsActive: true},
+		{UserID: "u2", IsActive: true},
+		{UserID: "u3", IsActive: true},
+	}
+	counts := map[UserID]int64{"u1": 6, "u2": 2, "u3": 4}
+
+	tf := NewTeamFairness("backend", members, counts, from, to)
+
+	if tf.Spread != 2 {
+		t.Errorf("Spread = %v, want 2", tf.Spread)
+	}
+	if tf.TotalAssignments != 12 {
+		t.Errorf("TotalAssignments = %d, want 12", tf.TotalAssignments)
+	}
+	var ids []UserID
+	for _, m := range tf.Members {
+		ids = append(ids, m.UserID)
+	}
+	if want := []UserID{"u1", "u2", "u3"}; !slices.Equal(ids, want) {
+		t.Errorf("members = %v, want %v", ids, want)
+	}
+}
+
+func TestActiveDuration(t *testing.T) {
+	from := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(10 * 24 * time.Hour)
+	day := func(n int) time.Time { return from.Add(time.Duration(n) * 24 * time.Hour) }
+
+	tests := []struct {
+		name     string
+		member   MemberActivity
+		wantDays int
+	}{
+		{"always active", MemberActivity{IsActive: true}, 10},
+		{"always inactive", MemberActivity{IsActive: false}, 0},
+		{
+			"deactivated inside window",
+			MemberActivity{Changes: []ActivityChange{{IsActive: true, ChangedAt: day(-5)}, {IsActive: false, ChangedAt: day(3)}}},
+			3,
+		},
+		{
+			"activated inside window",
+			MemberActivity{IsActive: true, Changes: []ActivityChange{{IsActive: false, ChangedAt: day(-1)}, {IsActive: true, ChangedAt: day(2)}}},
+			8,
+		},
+		{
+			"toggled twice",
+			MemberActivity{IsActive: true, Changes: []ActivityChange{
+				{IsActive: true, ChangedAt: day(1)},
+				{IsActive: false, ChangedAt: day(2)},
+				{IsActive: true, ChangedAt: day(8)},
+			}},
+			3,
+		},
+		{
+			"activated after window",
+			MemberActivity{IsActive: true, Changes: []ActivityChange{{IsActive: true, ChangedAt: day(11)}}},
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		want := time.Duration(tt.wantDays) * 24 * time.Hour
+		if got := tt.member.ActiveDuration(from, to); got != want {
+			t.Errorf("%s: ActiveDuration = %v, want %v", tt.name, got, want)
+		}
+	}
+}
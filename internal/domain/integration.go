@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type ExternalProvider string
+
+const (
+	ProviderGitHub ExternalProvider = "github"
+	ProviderGitLab ExternalProvider = "gitlab"
+)
+
+func (p ExternalProvider) Validate() error {
+	switch p {
+	case ProviderGitHub, ProviderGitLab:
+		return nil
+	default:
+		return NewValidationError("provider", "unknown provider")
+	}
+}
+
+// ExternalUserMapping links a login on a code hosting provider to a user.
+// ExternalID is the provider's numeric user id, 0 when unknown; GitLab hooks
+// identify the merge request author only by it.
+type ExternalUserMapping struct {
+	Provider   ExternalProvider
+	Login      string
+	ExternalID int64
+	UserID     UserID
+}
+
+func (m ExternalUserMapping) Validate() error {
+	if err := m.Provider.Validate(); err != nil {
+		return err
+	}
+	if m.Login == "" {
+		return NewValidationError("login", "must not be empty")
+	}
+	if m.ExternalID < 0 {
+		return NewValidationError("external_id", "must be positive")
+	}
+	if m.UserID == "" {
+		return NewValidationError("user_id", "must not be empty")
+	}
+	return nil
+}
+
+type ExternalPRAction string
+
+const (
+	ExternalPROpened         ExternalPRAction = "opened"
+	ExternalPRReopened       ExternalPRAction = "reopened"
+	ExternalPRClosed         ExternalPRAction = "closed"
+	ExternalPRReadyForReview ExternalPRAction = "ready_for_review"
+)
+
+// ExternalPREvent is a pull request event received from a provider webhook,
+// with logins not yet resolved to users. AuthorExternalID, when set, takes
+// precedence over AuthorLogin.
+type ExternalPREvent struct {
+	Provider         ExternalProvider
+	Action           ExternalPRAction
+	Repository       string
+	Number           int
+	Title            string
+	AuthorLogin      string
+	AuthorExternalID int64
+	ActorLogin       string
+	Draft            bool
+	Merged           bool
+	MergedAt         *time.Time
+}
+
+// PullRequestID identifies external PRs the way the provider refers to
+// them: "owner/repo#N" on GitHub and "group/project!N" on GitLab.
+func (e ExternalPREvent) PullRequestID() PullRequestID {
+	sep := "#"
+	if e.Provider == ProviderGitLab {
+		sep = "!"
+	}
+	return PullRequestID(fmt.Sprintf("%s%s%d", e.Repository, sep, e.Number))
+}
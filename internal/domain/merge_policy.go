@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+type MergeCondition string
+
+const (
+	MergeConditionRequiredApprovals MergeCondition = "required_approvals"
+	MergeConditionChangesRequested  MergeCondition = "changes_requested"
+	MergeConditionAllApproved       MergeCondition = "all_approved"
+)
+
+// MergeBlocker is a merge policy condition the pull request does not meet.
+// Required and Actual count approvals; Reviewers lists who requested changes.
+type MergeBlocker struct {
+	Condition MergeCondition
+	Required  int
+	Actual    int
+	Reviewers []UserID
+}
+
+func (b MergeBlocker) String() string {
+	switch b.Condition {
+	case MergeConditionRequiredApprovals:
+		return fmt.Sprintf("%d of %d required approvals", b.Actual, b.Required)
+	case MergeConditionChangesRequested:
+		ids := make([]string, 0, len(b.Reviewers))
+		for _, id := range b.Reviewers {
+			ids = append(ids, string(id))
+		}
+		return "changes requested by " + strings.Join(ids, ", ")
+	case MergeConditionAllApproved:
+		return fmt.Sprintf("%d of %d assigned reviewers approved", b.Actual, b.Required)
+	}
+	return string(b.Condition)
+}
+
+// MergeBlockedError is returned when a merge is refused by the team policy.
+// It is a MERGE_BLOCKED DomainError that also carries the unmet conditions.
+type MergeBlockedError struct {
+	*DomainError
+	Blockers []MergeBlocker
+}
+
+func NewMergeBlockedError(blockers []MergeBlocker) *MergeBlockedError {
+	return &MergeBlockedError{
+		DomainError: NewDomainError(ErrMergeBlocked, "merge blocked: "+JoinMergeBlockers(blockers)),
+		Blockers:    blockers,
+	}
+}
+
+func (e *MergeBlockedError) Unwrap() error {
+	return e.DomainError
+}
+
+// JoinMergeBlockers describes blockers in one line, separated by "; ".
+func JoinMergeBlockers(blockers []MergeBlocker) string {
+	parts := make([]string, 0, len(blockers))
+	for _, b := range blockers {
+		parts = append(parts, b.String())
+	}
+	return strings.Join(parts, "; ")
+}
+
+// MergeBlockers returns the merge policy conditions the pull request does not meet yet.
+func (s TeamSettings) MergeBlockers(pr PullRequest) []MergeBlocker {
+	var (
+		blockers  []MergeBlocker
+		approved  int
+		changesBy []UserID
+	)
+
+	for _, rv := range pr.Reviews {
+		switch rv.State {
+		case ReviewStateApproved:
+			approved++
+		case ReviewStateChangesRequested:
+			changesBy = append(changesBy, rv.ReviewerID)
+		}
+	}
+
+	if approved < s.RequiredApprovals {
+		blockers = append(blockers, MergeBlocker{Condition: MergeConditionRequiredApprovals, Required: s.RequiredApprovals, Actual: approved})
+	}
+	if s.BlockOnChangesRequested && len(changesBy) > 0 {
+		blockers = append(blockers, MergeBlocker{Condition: MergeConditionChangesRequested, Reviewers: changesBy})
+	}
+	if s.RequireAllApproved && approved < len(pr.Reviews) {
+		blockers = append(blockers, MergeBlocker{Condition: MergeConditionAllApproved, Required: len(pr.Reviews), Actual: approved})
+	}
+
+	return blockers
+}
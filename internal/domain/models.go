@@ -9,15 +9,143 @@ type (
 )
 
 type User struct {
-	ID       UserID
-	Username string
-	TeamName TeamName
-	IsActive bool
+	ID          UserID
+	Username    string
+	TeamName    TeamName
+	IsActive    bool
+	SlackHandle string
+	Email       string
+	// MaxOpenReviews caps the user's concurrent open reviews. Nil means the
+	// team default applies; zero exempts the user from any limit.
+	MaxOpenReviews *int
+	// Skills are expertise tags matched against PR labels.
+	Skills []string
+}
+
+// MatchingSkills returns the user's skills that appear among labels.
+func (u User) MatchingSkills(labels []string) []string {
+	var matched []string
+	for _, s := range u.Skills {
+		for _, l := range labels {
+			if s == l {
+				matched = append(matched, s)
+				break
+			}
+		}
+	}
+	return matched
 }
 
 type Team struct {
-	Name    TeamName
-	Members []User
+	Name             TeamName
+	Members          []User
+	ReviewerStrategy ReviewerStrategy
+}
+
+const (
+	DefaultMinReviewers            = 0
+	DefaultMaxReviewers            = 2
+	DefaultRequiredApprovals       = 0
+	DefaultBlockOnChangesRequested = true
+	DefaultRequireAllApproved      = false
+	DefaultReminderAfterHours      = 24
+	DefaultEscalateAfterHours      = 72
+	DefaultMaxOpenReviews          = 0
+)
+
+type TeamSettings struct {
+	TeamName                TeamName
+	MinReviewers            int
+	MaxReviewers            int
+	RequiredApprovals       int
+	BlockOnChangesRequested bool
+	RequireAllApproved      bool
+	SlackWebhookURL         string
+	ReminderAfterHours      int
+	EscalateAfterHours      int
+	MaxOpenReviews          int
+}
+
+// TeamSettingsPatch holds a partial update of team settings: nil fields are left unchanged.
+type TeamSettingsPatch struct {
+	MinReviewers            *int
+	MaxReviewers            *int
+	RequiredApprovals       *int
+	BlockOnChangesRequested *bool
+	RequireAllApproved      *bool
+	SlackWebhookURL         *string
+	ReminderAfterHours      *int
+	EscalateAfterHours      *int
+	MaxOpenReviews          *int
+}
+
+func DefaultTeamSettings(name TeamName) TeamSettings {
+	return TeamSettings{
+		TeamName:                name,
+		MinReviewers:            DefaultMinReviewers,
+		MaxReviewers:            DefaultMaxReviewers,
+		RequiredApprovals:       DefaultRequiredApprovals,
+		BlockOnChangesRequested: DefaultBlockOnChangesRequested,
+		RequireAllApproved:      DefaultRequireAllApproved,
+		ReminderAfterHours:      DefaultReminderAfterHours,
+		EscalateAfterHours:      DefaultEscalateAfterHours,
+		MaxOpenReviews:          DefaultMaxOpenReviews,
+	}
+}
+
+func (s TeamSettings) Apply(p TeamSettingsPatch) TeamSettings {
+	if p.MinReviewers != nil {
+		s.MinReviewers = *p.MinReviewers
+	}
+	if p.MaxReviewers != nil {
+		s.MaxReviewers = *p.MaxReviewers
+	}
+	if p.RequiredApprovals != nil {
+		s.RequiredApprovals = *p.RequiredApprovals
+	}
+	if p.BlockOnChangesRequested != nil {
+		s.BlockOnChangesRequested = *p.BlockOnChangesRequested
+	}
+	if p.RequireAllApproved != nil {
+		s.RequireAllApproved = *p.RequireAllApproved
+	}
+	if p.SlackWebhookURL != nil {
+		s.SlackWebhookURL = *p.SlackWebhookURL
+	}
+	if p.ReminderAfterHours != nil {
+		s.ReminderAfterHours = *p.ReminderAfterHours
+	}
+	if p.EscalateAfterHours != nil {
+		s.EscalateAfterHours = *p.EscalateAfterHours
+	}
+	if p.MaxOpenReviews != nil {
+		s.MaxOpenReviews = *p.MaxOpenReviews
+	}
+	return s
+}
+
+type ReviewerStrategy string
+
+const (
+	ReviewerStrategyRandom         ReviewerStrategy = "RANDOM"
+	ReviewerStrategyLeastLoaded    ReviewerStrategy = "LEAST_LOADED"
+	ReviewerStrategyRoundRobin     ReviewerStrategy = "ROUND_ROBIN"
+	ReviewerStrategyWeightedRandom ReviewerStrategy = "WEIGHTED_RANDOM"
+)
+
+// ReviewCapacity is how many open reviews the user may hold at once under
+// the team settings; zero means there is no limit.
+func ReviewCapacity(u User, s TeamSettings) int {
+	if u.MaxOpenReviews != nil {
+		return *u.MaxOpenReviews
+	}
+	return s.MaxOpenReviews
+}
+
+type ReviewerLoad struct {
+	UserID         UserID
+	OpenReviews    int
+	LastAssignedAt *time.Time
 }
 
 type PRStatus string
@@ -25,14 +153,101 @@ type PRStatus string
 const (
 	PRStatusOpen   PRStatus = "OPEN"
 	PRStatusMerged PRStatus = "MERGED"
+	PRStatusClosed PRStatus = "CLOSED"
+	PRStatusDraft  PRStatus = "DRAFT"
+)
+
+type ReviewState string
+
+const (
+	ReviewStatePending          ReviewState = "PENDING"
+	ReviewStateApproved         ReviewState = "APPROVED"
+	ReviewStateChangesRequested ReviewState = "CHANGES_REQUESTED"
 )
 
+type Review struct {
+	ReviewerID UserID
+	State      ReviewState
+	ReviewedAt *time.Time
+}
+
 type PullRequest struct {
 	ID                PullRequestID
 	Name              string
 	AuthorID          UserID
 	Status            PRStatus
 	AssignedReviewers []UserID
+	Reviews           []Review
+	Labels            []string
+	ChangedFiles      []string
 	CreatedAt         *time.Time
 	MergedAt          *time.Time
+	ClosedAt          *time.Time
+}
+
+func PendingReviews(reviewers []UserID) []Review {
+	reviews := make([]Review, 0, len(reviewers))
+	for _, id := range reviewers {
+		reviews = append(reviews, Review{ReviewerID: id, State: ReviewStatePending})
+	}
+	return reviews
+}
+
+type AssignmentReason string
+
+const (
+	AssignmentReasonInitial        AssignmentReason = "initial"
+	AssignmentReasonReassign       AssignmentReason = "reassign"
+	AssignmentReasonBulkDeactivate AssignmentReason = "bulk_deactivate"
+	AssignmentReasonManual         AssignmentReason = "manual"
+	AssignmentReasonSLAEscalation  AssignmentReason = "sla_escalation"
+	AssignmentReasonAbsence        AssignmentReason = "absence"
+	AssignmentReasonBacklog        AssignmentReason = "backlog"
+)
+
+// BacklogEntry is an open PR that got fewer reviewers than its team wanted.
+// Missing is how many reviewer slots are still empty.
+type BacklogEntry struct {
+	PullRequestID     PullRequestID
+	Name              string
+	AuthorID          UserID
+	TeamName          TeamName
+	AssignedReviewers []UserID
+	Missing           int
+	CreatedAt         time.Time
+	LastAttemptAt     *time.Time
+}
+
+type ReviewAssignment struct {
+	ID            int64
+	PullRequestID PullRequestID
+	ReviewerID    UserID
+	AssignedAt    time.Time
+	UnassignedAt  *time.Time
+	Reason        AssignmentReason
+	Actor         UserID
+}
+
+type PREventType string
+
+const (
+	PREventCreated          PREventType = "created"
+	PREventReviewerAssigned PREventType = "reviewer_assigned"
+	PREventReviewerReplaced PREventType = "reviewer_replaced"
+	PREventReviewSubmitted  PREventType = "review_submitted"
+	PREventReadyForReview   PREventType = "ready_for_review"
+	PREventClosed           PREventType = "closed"
+	PREventReopened         PREventType = "reopened"
+	PREventMerged           PREventType = "merged"
+)
+
+type PREvent struct {
+	ID            int64
+	PullRequestID PullRequestID
+	Type          PREventType
+	Actor         UserID
+	ReviewerID    UserID
+	OldReviewerID UserID
+	Details       string
+	CreatedAt     time.Time
 }
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+type SLAAction string
+
+const (
+	SLAActionNone     SLAAction = ""
+	SLAActionRemind   SLAAction = "remind"
+	SLAActionEscalate SLAAction = "escalate"
+)
+
+// PendingReview is a review of an open PR that the reviewer has not
+// submitted yet, together with the SLA of the reviewer's team.
+// Since is when the SLA clock started: the assignment, or the reopening of
+// the PR if that was later. A zero ReminderAfter or EscalateAfter disables
+// that step. EscalatedAt is set when an escalation was attempted but found
+// no replacement.
+type PendingReview struct {
+	PullRequestID PullRequestID
+	ReviewerID    UserID
+	TeamName      TeamName
+	Since         time.Time
+	RemindedAt    *time.Time
+	EscalatedAt   *time.Time
+	ReminderAfter time.Duration
+	EscalateAfter time.Duration
+}
+
+// Due reports what has to be done about the review at now. Escalation
+// wins over a reminder; each step happens at most once per assignment.
+func (r PendingReview) Due(now time.Time) SLAAction {
+	age := now.Sub(r.Since)
+	if r.EscalateAfter > 0 && age >= r.EscalateAfter && r.EscalatedAt == nil {
+		return SLAActionEscalate
+	}
+	if r.ReminderAfter > 0 && age >= r.ReminderAfter && r.RemindedAt == nil {
+		return SLAActionRemind
+	}
+	return SLAActionNone
+}
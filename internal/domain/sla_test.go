@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPendingReviewDue(t *testing.T) {
+	since := time.Date(2025, 12, 1, 9, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return since.Add(time.Duration(hours) * time.Hour) }
+	done := at(1)
+
+	tests := []struct {
+		name   string
+		review PendingReview
+		now    time.Time
+		want   SLAAction
+	}{
+		{"too early", PendingReview{ReminderAfter: 24 * time.Hour, EscalateAfter: 72 * time.Hour}, at(23), SLAActionNone},
+		{"reminder due", PendingReview{ReminderAfter: 24 * time.Hour, EscalateAfter: 72 * time.Hour}, at(24), SLAActionRemind},
+		{"already reminded", PendingReview{ReminderAfter: 24 * time.Hour, EscalateAfter: 72 * time.Hour, RemindedAt: &done}, at(30), SLAActionNone},
+		{"escalation wins", PendingReview{ReminderAfter: 24 * time.Hour, EscalateAfter: 72 * time.Hour}, at(72), SLAActionEscalate},
+		{"escalation after reminder", PendingReview{ReminderAfter: 24 * time.Hour, EscalateAfter: 72 * time.Hour, RemindedAt: &done}, at(80), SLAActionEscalate},
+		{"already escalated", PendingReview{ReminderAfter: 24 * time.Hour, EscalateAfter: 72 * time.Hour, RemindedAt: &done, EscalatedAt: &done}, at(100), SLAActionNone},
+		{"escalated but not reminded", PendingReview{ReminderAfter: 24 * time.Hour, EscalateAfter: 72 * time.Hour, EscalatedAt: &done}, at(100), SLAActionRemind},
+		{"reminder disabled", PendingReview{EscalateAfter: 72 * time.Hour}, at(48), SLAActionNone},
+		{"reminder disabled, escalation due", PendingReview{EscalateAfter: 72 * time.Hour}, at(72), SLAActionEscalate},
+		{"escalation disabled", PendingReview{ReminderAfter: 24 * time.Hour}, at(1000), SLAActionRemind},
+		{"both disabled", PendingReview{}, at(1000), SLAActionNone},
+	}
+
+	for _, tt := range tests {
+		tt.review.Since = since
+		if got := tt.review.Due(tt.now); got != tt.want {
+			t.Errorf("%s: Due = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -1,7 +1,73 @@
 package domain
 
+import "time"
+
 type ReviewerStats struct {
 	UserID        UserID
 	Username      string
 	AssignedCount int64
+	OpenCount     int64
+}
+
+type ReviewerStatsFilter struct {
+	From     *time.Time
+	To       *time.Time
+	TeamName TeamName
+	Status   PRStatus
+}
+
+func (f ReviewerStatsFilter) Validate() error {
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return NewValidationError("from", "must be before to")
+	}
+	switch f.Status {
+	case "", PRStatusOpen, PRStatusMerged:
+		return nil
+	default:
+		return NewValidationError("status", "must be open or merged")
+	}
+}
+
+type LatencyMetric string
+
+const (
+	LatencyTimeToFirstReview LatencyMetric = "first_review"
+	LatencyTimeToMerge       LatencyMetric = "merge"
+)
+
+type LatencyPercentiles struct {
+	Count      int64
+	P50Seconds float64
+	P90Seconds float64
+}
+
+type TeamLatency struct {
+	TeamName          TeamName
+	TimeToFirstReview LatencyPercentiles
+	TimeToMerge       LatencyPercentiles
+}
+
+type ReviewerLatency struct {
+	UserID            UserID
+	Username          string
+	TimeToFirstReview LatencyPercentiles
+	TimeToMerge       LatencyPercentiles
+}
+
+type LatencyFilter struct {
+	From     *time.Time
+	To       *time.Time
+	TeamName TeamName
+}
+
+func (f LatencyFilter) Validate() error {
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return NewValidationError("from", "must be before to")
+	}
+	return nil
+}
+
+type LatencyReport struct {
+	Teams     []TeamLatency
+	Reviewers []ReviewerLatency
 }
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{"nil stays nil", nil, nil},
+		{"lowercased and trimmed", []string{" DB ", "Go"}, []string{"db", "go"}},
+		{"duplicates dropped in order", []string{"go", "db", "GO", "db"}, []string{"go", "db"}},
+		{"blank tags dropped", []string{"", "  ", "api"}, []string{"api"}},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeTags(tt.tags); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: NormalizeTags = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	tooMany := make([]string, maxTags+1)
+	for i := range tooMany {
+		tooMany[i] = "t"
+	}
+
+	tests := []struct {
+		name    string
+		tags    []string
+		wantErr bool
+	}{
+		{"none", nil, false},
+		{"allowed characters", []string{"db", "k8s", "front-end", "ci.cd", "x_y"}, false},
+		{"at the limit", tooMany[:maxTags], false},
+		{"too many", tooMany, true},
+		{"uppercase", []string{"DB"}, true},
+		{"starts with a dash", []string{"-db"}, true},
+		{"space inside", []string{"data base"}, true},
+		{"too long", []string{strings.Repeat("a", maxTagLength+1)}, true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateTags("skills", tt.tags)
+		if !tt.wantErr {
+			if err != nil {
+				t.Errorf("%s: ValidateTags = %v, want nil", tt.name, err)
+			}
+			continue
+		}
+		var ve *ValidationError
+		if !errors.As(err, &ve) || ve.Field != "skills" {
+			t.Errorf("%s: ValidateTags = %v, want a validation error on skills", tt.name, err)
+		}
+	}
+}
+
+func TestMatchingSkills(t *testing.T) {
+	u := User{Skills: []string{"go", "db", "k8s"}}
+
+	tests := []struct {
+		name   string
+		labels []string
+		want   []string
+	}{
+		{"no labels", nil, nil},
+		{"no overlap", []string{"frontend"}, nil},
+		{"in skill order", []string{"k8s", "go"}, []string{"go", "k8s"}},
+		{"duplicate labels", []string{"db", "db"}, []string{"db"}},
+	}
+
+	for _, tt := range tests {
+		if got := u.MatchingSkills(tt.labels); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: MatchingSkills = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,19 @@
 package domain
 
-import "fmt"
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	maxTags      = 20
+	maxTagLength = 50
+)
+
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
 
 func (u User) Validate() error {
 	if u.ID == "" {
@@ -9,9 +22,45 @@ func (u User) Validate() error {
 	if u.Username == "" {
 		return NewValidationError("username", "must not be empty")
 	}
+	if u.Email != "" {
+		if err := ValidateEmail(u.Email); err != nil {
+			return err
+		}
+	}
 	if u.TeamName == "" {
 		return NewValidationError("team_name", "must not be empty")
 	}
+	if u.MaxOpenReviews != nil && *u.MaxOpenReviews < 0 {
+		return NewValidationError("max_open_reviews", "must not be negative")
+	}
+	return ValidateTags("skills", u.Skills)
+}
+
+// NormalizeTags trims and lowercases skill tags and labels and drops empty
+// and repeated ones, keeping the order.
+func NormalizeTags(tags []string) []string {
+	var result []string
+	seen := make(map[string]struct{}, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if _, ok := seen[t]; ok || t == "" {
+			continue
+		}
+		seen[t] = struct{}{}
+		result = append(result, t)
+	}
+	return result
+}
+
+func ValidateTags(field string, tags []string) error {
+	if len(tags) > maxTags {
+		return NewValidationError(field, fmt.Sprintf("must contain at most %d tags", maxTags))
+	}
+	for _, t := range tags {
+		if len(t) > maxTagLength || !tagPattern.MatchString(t) {
+			return NewValidationError(field, fmt.Sprintf("tag %q must be lowercase letters, digits, '.', '_' or '-'", t))
+		}
+	}
 	return nil
 }
 
@@ -19,6 +68,11 @@ func (t Team) Validate() error {
 	if t.Name == "" {
 		return NewValidationError("team_name", "must not be empty")
 	}
+	if t.ReviewerStrategy != "" {
+		if err := t.ReviewerStrategy.Validate(); err != nil {
+			return err
+		}
+	}
 
 	for i, m := range t.Members {
 		if err := m.Validate(); err != nil {
@@ -28,12 +82,117 @@ func (t Team) Validate() error {
 	return nil
 }
 
+func (s TeamSettings) Validate() error {
+	if s.TeamName == "" {
+		return NewValidationError("team_name", "must not be empty")
+	}
+	if s.MinReviewers < 0 {
+		return NewValidationError("min_reviewers", "must not be negative")
+	}
+	if s.MaxReviewers < 1 {
+		return NewValidationError("max_reviewers", "must be at least 1")
+	}
+	if s.MinReviewers > s.MaxReviewers {
+		return NewValidationError("min_reviewers", "must not exceed max_reviewers")
+	}
+	if s.RequiredApprovals < 0 {
+		return NewValidationError("required_approvals", "must not be negative")
+	}
+	if s.RequiredApprovals > s.MaxReviewers {
+		return NewValidationError("required_approvals", "must not exceed max_reviewers")
+	}
+	if s.SlackWebhookURL != "" && !isHTTPURL(s.SlackWebhookURL) {
+		return NewValidationError("slack_webhook_url", "must be an absolute http(s) URL")
+	}
+	if s.ReminderAfterHours < 0 {
+		return NewValidationError("reminder_after_hours", "must not be negative")
+	}
+	if s.EscalateAfterHours < 0 {
+		return NewValidationError("escalate_after_hours", "must not be negative")
+	}
+	if s.ReminderAfterHours > 0 && s.EscalateAfterHours > 0 && s.EscalateAfterHours <= s.ReminderAfterHours {
+		return NewValidationError("escalate_after_hours", "must be greater than reminder_after_hours")
+	}
+	if s.MaxOpenReviews < 0 {
+		return NewValidationError("max_open_reviews", "must not be negative")
+	}
+	return nil
+}
+
+const (
+	maxFallbackTeams = 5
+	maxChangedFiles  = 3000
+)
+
+// ValidateFallbackTeams checks the ordered list of teams that lend reviewers
+// to team when it runs out of candidates.
+func ValidateFallbackTeams(team TeamName, fallbacks []TeamName) error {
+	if team == "" {
+		return NewValidationError("team_name", "must not be empty")
+	}
+	if len(fallbacks) > maxFallbackTeams {
+		return NewValidationError("fallback_teams", fmt.Sprintf("must contain at most %d teams", maxFallbackTeams))
+	}
+
+	seen := make(map[TeamName]struct{}, len(fallbacks))
+	for _, f := range fallbacks {
+		if f == "" {
+			return NewValidationError("fallback_teams", "must not contain empty team names")
+		}
+		if f == team {
+			return NewValidationError("fallback_teams", "must not contain the team itself")
+		}
+		if _, ok := seen[f]; ok {
+			return NewValidationError("fallback_teams", "must not contain duplicates")
+		}
+		seen[f] = struct{}{}
+	}
+	return nil
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func (s ReviewerStrategy) Validate() error {
+	switch s {
+	case ReviewerStrategyRandom, ReviewerStrategyLeastLoaded, ReviewerStrategyRoundRobin, ReviewerStrategyWeightedRandom:
+		return nil
+	default:
+		return NewValidationError("reviewer_strategy", "must be RANDOM, LEAST_LOADED, ROUND_ROBIN or WEIGHTED_RANDOM")
+	}
+}
+
+// RequireOpen returns the error for performing action on a PR that is not
+// open.
+func (s PRStatus) RequireOpen(action string) error {
+	switch s {
+	case PRStatusMerged:
+		return NewDomainError(ErrPRMerged, "cannot "+action+" merged PR")
+	case PRStatusClosed:
+		return NewDomainError(ErrPRClosed, "cannot "+action+" closed PR")
+	case PRStatusDraft:
+		return NewDomainError(ErrPRDraft, "cannot "+action+" draft PR")
+	}
+	return nil
+}
+
 func (s PRStatus) Validate() error {
 	switch s {
-	case PRStatusOpen, PRStatusMerged:
+	case PRStatusOpen, PRStatusMerged, PRStatusClosed, PRStatusDraft:
 		return nil
 	default:
-		return NewValidationError("status", "must be OPEN or MERGED")
+		return NewValidationError("status", "must be OPEN, MERGED, CLOSED or DRAFT")
+	}
+}
+
+func (s ReviewState) Validate() error {
+	switch s {
+	case ReviewStatePending, ReviewStateApproved, ReviewStateChangesRequested:
+		return nil
+	default:
+		return NewValidationError("state", "must be PENDING, APPROVED or CHANGES_REQUESTED")
 	}
 }
 
@@ -50,10 +209,6 @@ func (pr PullRequest) Validate() error {
 	if err := pr.Status.Validate(); err != nil {
 		return err
 	}
-	if len(pr.AssignedReviewers) > 2 {
-		return NewValidationError("assigned_reviewers", "must contain at most 2 reviewers")
-	}
-
 	seen := make(map[UserID]struct{}, len(pr.AssignedReviewers))
 	for i, r := range pr.AssignedReviewers {
 		if r == "" {
@@ -64,6 +219,39 @@ func (pr PullRequest) Validate() error {
 		}
 		seen[r] = struct{}{}
 	}
+	if len(pr.ChangedFiles) > maxChangedFiles {
+		return NewValidationError("changed_files", fmt.Sprintf("must contain at most %d paths", maxChangedFiles))
+	}
+	for i, f := range pr.ChangedFiles {
+		if f == "" {
+			return NewValidationError(fmt.Sprintf("changed_files[%d]", i), "must not be empty")
+		}
+	}
+	if err := ValidateTags("labels", pr.Labels); err != nil {
+		return err
+	}
 
 	return nil
 }
+
+// ValidateReviewerCount checks the PR against the team's reviewer bounds.
+// Candidates skipped only because they are at review capacity count towards
+// the minimum: such a PR is created with the slots left unfilled.
+func (pr PullRequest) ValidateReviewerCount(settings TeamSettings, atCapacity int) error {
+	n := len(pr.AssignedReviewers)
+	if n > settings.MaxReviewers {
+		return NewValidationError("assigned_reviewers", fmt.Sprintf("must contain at most %d reviewers", settings.MaxReviewers))
+	}
+	if n+atCapacity < settings.MinReviewers {
+		return NewValidationError("assigned_reviewers", fmt.Sprintf("must contain at least %d reviewers", settings.MinReviewers))
+	}
+	return nil
+}
+
+func ValidateEmail(email string) error {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return NewValidationError("email", "must be a plain email address")
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package domain
+
+import "time"
+
+// WebhookSubscription receives every event type when EventTypes is empty.
+type WebhookSubscription struct {
+	ID         int64
+	URL        string
+	Secret     string
+	EventTypes []EventType
+	IsActive   bool
+	CreatedAt  time.Time
+}
+
+func (s WebhookSubscription) Validate() error {
+	if !isHTTPURL(s.URL) {
+		return NewValidationError("url", "must be an absolute http(s) URL")
+	}
+	for _, t := range s.EventTypes {
+		if err := t.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type WebhookSubscriptionPatch struct {
+	URL        *string
+	Secret     *string
+	EventTypes *[]EventType
+	IsActive   *bool
+}
+
+func (s WebhookSubscription) Apply(p WebhookSubscriptionPatch) WebhookSubscription {
+	if p.URL != nil {
+		s.URL = *p.URL
+	}
+	if p.Secret != nil {
+		s.Secret = *p.Secret
+	}
+	if p.EventTypes != nil {
+		s.EventTypes = *p.EventTypes
+	}
+	if p.IsActive != nil {
+		s.IsActive = *p.IsActive
+	}
+	return s
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "DELIVERED"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "FAILED"
+)
+
+func (s WebhookDeliveryStatus) Validate() error {
+	switch s {
+	case WebhookDeliveryPending, WebhookDeliveryDelivered, WebhookDeliveryFailed:
+		return nil
+	default:
+		return NewValidationError("status", "must be PENDING, DELIVERED or FAILED")
+	}
+}
+
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	EventType      EventType
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	ResponseStatus int
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+const (
+	MaxWebhookAttempts  = 8
+	webhookBackoffBase  = 30 * time.Second
+	webhookBackoffLimit = time.Hour
+)
+
+// WebhookBackoff returns the delay before the next attempt after the given
+// number of failed attempts: 30s, 1m, 2m, ... capped at one hour.
+func WebhookBackoff(attempts int) time.Duration {
+	d := webhookBackoffBase
+	for i := 1; i < attempts && d < webhookBackoffLimit; i++ {
+		d *= 2
+	}
+	return min(d, webhookBackoffLimit)
+}
+
+// WebhookDispatch is a claimed delivery together with where to send it.
+type WebhookDispatch struct {
+	Delivery WebhookDelivery
+	URL      string
+	Secret   string
+}
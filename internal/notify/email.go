@@ -0,0 +1,206 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/*.txt templates/*.html
+var templateFS embed.FS
+
+// SMTPConfig describes the relay. Timeout bounds a whole send, including
+// the dial; a sooner context deadline wins.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration
+}
+
+const defaultSMTPTimeout = 10 * time.Second
+
+// EmailNotifier sends multipart text/html mail through an SMTP relay.
+// Recipients without an email address are skipped.
+type EmailNotifier struct {
+	cfg  SMTPConfig
+	text map[Kind]*texttemplate.Template
+	html map[Kind]*htmltemplate.Template
+}
+
+// Every kind has its own template pair, each defining "subject" and "text"
+// (templates/<kind>.txt) or "html" (templates/<kind>.html).
+func NewEmailNotifier(cfg SMTPConfig) (*EmailNotifier, error) {
+	n := &EmailNotifier{
+		cfg:  cfg,
+		text: make(map[Kind]*texttemplate.Template),
+		html: make(map[Kind]*htmltemplate.Template),
+	}
+
+	for _, kind := range []Kind{KindAssigned, KindUnassigned, KindMerged, KindReminder} {
+		text, err := texttemplate.ParseFS(templateFS, "templates/"+string(kind)+".txt")
+		if err != nil {
+			return nil, fmt.Errorf("parse %s text template: %w", kind, err)
+		}
+		html, err := htmltemplate.ParseFS(templateFS, "templates/"+string(kind)+".html")
+		if err != nil {
+			return nil, fmt.Errorf("parse %s html template: %w", kind, err)
+		}
+		n.text[kind] = text
+		n.html[kind] = html
+	}
+
+	return n, nil
+}
+
+func (n *EmailNotifier) Name() string { return "email" }
+
+func (n *EmailNotifier) Notify(ctx context.Context, m Message) error {
+	if m.Recipient.Email == "" {
+		return nil
+	}
+
+	msg, err := n.Render(m)
+	if err != nil {
+		return err
+	}
+
+	if err := n.send(ctx, m.Recipient.Email, msg); err != nil {
+		return fmt.Errorf("send mail to %s: %w", m.Recipient.ID, err)
+	}
+	return nil
+}
+
+// send does what smtp.SendMail does, but over a connection bounded by ctx
+// and the configured timeout.
+func (n *EmailNotifier) send(ctx context.Context, to string, msg []byte) error {
+	timeout := n.cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultSMTPTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(n.cfg.Host, strconv.Itoa(n.cfg.Port)))
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return err
+	}
+	// Unblock reads and writes as soon as ctx is cancelled.
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, n.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: n.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if n.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(n.cfg.From); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// Render builds the full RFC 5322 message for m.
+func (n *EmailNotifier) Render(m Message) ([]byte, error) {
+	kind := m.Kind
+
+	text, html := n.text[kind], n.html[kind]
+	if text == nil || html == nil {
+		return nil, fmt.Errorf("no email template for %q", kind)
+	}
+
+	var subject, textBody, htmlBody bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", m); err != nil {
+		return nil, fmt.Errorf("render %s subject: %w", kind, err)
+	}
+	if err := text.ExecuteTemplate(&textBody, "text", m); err != nil {
+		return nil, fmt.Errorf("render %s text: %w", kind, err)
+	}
+	if err := html.ExecuteTemplate(&htmlBody, "html", m); err != nil {
+		return nil, fmt.Errorf("render %s html: %w", kind, err)
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=utf-8", textBody.Bytes()},
+		{"text/html; charset=utf-8", htmlBody.Bytes()},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create mime part: %w", err)
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write(part.content); err != nil {
+			return nil, fmt.Errorf("write mime part: %w", err)
+		}
+		if err := qp.Close(); err != nil {
+			return nil, fmt.Errorf("write mime part: %w", err)
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("close mime writer: %w", err)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.cfg.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", m.Recipient.Email)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject.String()))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", mw.Boundary())
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
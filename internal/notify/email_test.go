@@ -0,0 +1,170 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+// smtpStandIn accepts a single mail and sends its DATA to the channel.
+func smtpStandIn(t *testing.T) (string, int, <-chan string) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	data := make(chan string, 1)
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+
+		reply("220 localhost ESMTP stand-in")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(cmd, "DATA"):
+				reply("354 end with <CRLF>.<CRLF>")
+				var b strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					b.WriteString(l)
+				}
+				data <- b.String()
+				reply("250 queued")
+			case strings.HasPrefix(cmd, "QUIT"):
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	addr := ln.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, data
+}
+
+func TestEmailNotifierSendsAssignment(t *testing.T) {
+	host, port, data := smtpStandIn(t)
+
+	n, err := NewEmailNotifier(SMTPConfig{Host: host, Port: port, From: "reviews@example.com"})
+	if err != nil {
+		t.Fatalf("new notifier: %v", err)
+	}
+
+	err = n.Notify(context.Background(), Message{
+		Kind:        KindAssigned,
+		Recipient:   domain.User{ID: "u2", Username: "Bob", Email: "bob@example.com"},
+		PR:          domain.PullRequest{ID: "pr-1001", Name: "Add search"},
+		Author:      domain.User{ID: "u1", Username: "Egor"},
+		Counterpart: &domain.User{ID: "u3", Username: "Alice"},
+	})
+	if err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+
+	select {
+	case msg := <-data:
+		for _, want := range []string{
+			"To: bob@example.com",
+			"Subject: Review requested: pr-1001 Add search",
+			"multipart/alternative",
+			"Content-Transfer-Encoding: quoted-printable",
+			"You were assigned to review pr-1001 'Add search' by Egor.",
+			"You are replacing Alice.",
+			"<b>pr-1001</b>",
+		} {
+			if !strings.Contains(msg, want) {
+				t.Errorf("message does not contain %q:\n%s", want, msg)
+			}
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no mail received")
+	}
+}
+
+func TestEmailNotifierStopsAtContextDeadline(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	// Accept the connection but never send the greeting.
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		t.Cleanup(func() { _ = conn.Close() })
+	}()
+
+	addr := ln.Addr().(*net.TCPAddr)
+	n, err := NewEmailNotifier(SMTPConfig{Host: addr.IP.String(), Port: addr.Port, From: "reviews@example.com", Timeout: time.Minute})
+	if err != nil {
+		t.Fatalf("new notifier: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = n.Notify(ctx, Message{
+		Kind:      KindMerged,
+		Recipient: domain.User{ID: "u1", Username: "Egor", Email: "egor@example.com"},
+		PR:        domain.PullRequest{ID: "pr-1", Name: "Fix"},
+	})
+	if err == nil {
+		t.Fatal("expected an error from a silent server")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("notify returned after %v, want it bounded by the context", elapsed)
+	}
+}
+
+func TestEmailNotifierRendersEveryKind(t *testing.T) {
+	n, err := NewEmailNotifier(SMTPConfig{From: "reviews@example.com"})
+	if err != nil {
+		t.Fatalf("new notifier: %v", err)
+	}
+
+	for _, kind := range []Kind{KindAssigned, KindUnassigned, KindMerged, KindReminder} {
+		_, err := n.Render(Message{
+			Kind:      kind,
+			Recipient: domain.User{Username: "Bob", Email: "bob@example.com"},
+			PR:        domain.PullRequest{ID: "pr-1", Name: "Fix"},
+			Author:    domain.User{Username: "Egor"},
+			Since:     time.Date(2025, 12, 1, 9, 0, 0, 0, time.UTC),
+		})
+		if err != nil {
+			t.Errorf("render %s: %v", kind, err)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+type PullRequestGetter interface {
+	GetByID(ctx context.Context, id domain.PullRequestID) (domain.PullRequest, error)
+}
+
+type UserGetter interface {
+	GetUserByID(ctx context.Context, id domain.UserID) (domain.User, error)
+}
+
+// EventSink is an outbox sink that turns assignment, reassignment and
+// merge events into messages for a notifier. A reassignment notifies two
+// people, so the replaced reviewer is told by a separate sink from
+// NewUnassignedEventSink: the outbox tracks the two deliveries on their own
+// and a failure of one never re-sends the other.
+type EventSink struct {
+	prs        PullRequestGetter
+	users      UserGetter
+	notifier   Notifier
+	unassigned bool
+}
+
+// NewEventSink notifies assigned reviewers and authors of merged PRs.
+func NewEventSink(prs PullRequestGetter, users UserGetter, notifier Notifier) *EventSink {
+	return &EventSink{
+		prs:      prs,
+		users:    users,
+		notifier: notifier,
+	}
+}
+
+// NewUnassignedEventSink notifies reviewers replaced by a reassignment.
+func NewUnassignedEventSink(prs PullRequestGetter, users UserGetter, notifier Notifier) *EventSink {
+	s := NewEventSink(prs, users, notifier)
+	s.unassigned = true
+	return s
+}
+
+func (s *EventSink) Name() string {
+	if s.unassigned {
+		return s.notifier.Name() + ".unassigned"
+	}
+	return s.notifier.Name()
+}
+
+type eventPayload struct {
+	PullRequestID string `json:"pull_request_id"`
+	Actor         string `json:"actor"`
+	UserID        string `json:"user_id"`
+	OldUserID     string `json:"old_user_id"`
+}
+
+func (s *EventSink) Handle(ctx context.Context, e domain.OutboxEvent) error {
+	switch {
+	case s.unassigned && e.Type == domain.EventReviewerReplaced:
+	case !s.unassigned && (e.Type == domain.EventReviewerAssigned || e.Type == domain.EventReviewerReplaced || e.Type == domain.EventPRMerged):
+	default:
+		return nil
+	}
+
+	var p eventPayload
+	if err := json.Unmarshal(e.Payload, &p); err != nil {
+		return fmt.Errorf("decode %s payload: %w", e.Type, err)
+	}
+
+	m, err := s.message(ctx, e.Type, p)
+	if domain.IsDomainError(err, domain.ErrNotFound) {
+		// The PR or a user is gone; there is nobody left to notify.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.notifier.Notify(ctx, m)
+}
+
+func (s *EventSink) message(ctx context.Context, t domain.EventType, p eventPayload) (Message, error) {
+	pr, err := s.prs.GetByID(ctx, domain.PullRequestID(p.PullRequestID))
+	if err != nil {
+		return Message{}, err
+	}
+
+	author, err := s.users.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		return Message{}, err
+	}
+
+	if t == domain.EventPRMerged {
+		return Message{Kind: KindMerged, Recipient: author, PR: pr, Author: author}, nil
+	}
+
+	reviewer, err := s.users.GetUserByID(ctx, domain.UserID(p.UserID))
+	if err != nil {
+		return Message{}, err
+	}
+
+	if t == domain.EventReviewerAssigned {
+		return Message{Kind: KindAssigned, Recipient: reviewer, PR: pr, Author: author}, nil
+	}
+
+	old, err := s.users.GetUserByID(ctx, domain.UserID(p.OldUserID))
+	if err != nil {
+		return Message{}, err
+	}
+
+	if !s.unassigned {
+		return Message{Kind: KindAssigned, Recipient: reviewer, PR: pr, Author: author, Counterpart: &old}, nil
+	}
+
+	m := Message{Kind: KindUnassigned, Recipient: old, PR: pr, Author: author, Counterpart: &reviewer}
+	if p.Actor != "" {
+		actor, err := s.users.GetUserByID(ctx, domain.UserID(p.Actor))
+		if err != nil && !domain.IsDomainError(err, domain.ErrNotFound) {
+			return Message{}, err
+		}
+		if err == nil {
+			m.Actor = &actor
+		}
+	}
+	return m, nil
+}
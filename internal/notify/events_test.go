@@ -0,0 +1,99 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+type prStub map[domain.PullRequestID]domain.PullRequest
+
+func (s prStub) GetByID(_ context.Context, id domain.PullRequestID) (domain.PullRequest, error) {
+	pr, ok := s[id]
+	if !ok {
+		return domain.PullRequest{}, domain.NewDomainError(domain.ErrNotFound, "pull request not found")
+	}
+	return pr, nil
+}
+
+type userStub map[domain.UserID]domain.User
+
+func (s userStub) GetUserByID(_ context.Context, id domain.UserID) (domain.User, error) {
+	u, ok := s[id]
+	if !ok {
+		return domain.User{}, domain.NewDomainError(domain.ErrNotFound, "user not found")
+	}
+	return u, nil
+}
+
+type recordingNotifier struct {
+	sent []Message
+	err  error
+}
+
+func (n *recordingNotifier) Name() string { return "recording" }
+
+func (n *recordingNotifier) Notify(_ context.Context, m Message) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.sent = append(n.sent, m)
+	return nil
+}
+
+func TestEventSinksSplitReassignment(t *testing.T) {
+	prs := prStub{"pr-1": {ID: "pr-1", Name: "Fix", AuthorID: "u1"}}
+	users := userStub{
+		"u1": {ID: "u1", Username: "Egor"},
+		"u2": {ID: "u2", Username: "Bob"},
+		"u3": {ID: "u3", Username: "Alice"},
+		"u4": {ID: "u4", Username: "Carol"},
+	}
+	e := domain.OutboxEvent{
+		ID:      1,
+		Type:    domain.EventReviewerReplaced,
+		Payload: []byte(`{"pull_request_id":"pr-1","actor":"u4","user_id":"u2","old_user_id":"u3"}`),
+	}
+
+	assigned := &recordingNotifier{}
+	if err := NewEventSink(prs, users, assigned).Handle(context.Background(), e); err != nil {
+		t.Fatalf("assigned sink: %v", err)
+	}
+	if len(assigned.sent) != 1 || assigned.sent[0].Kind != KindAssigned || assigned.sent[0].Recipient.ID != "u2" {
+		t.Fatalf("assigned sink sent %+v, want one assignment to u2", assigned.sent)
+	}
+
+	// The outbox tracks the two sinks under different names, so each
+	// message is retried on its own.
+	unassigned := &recordingNotifier{}
+	sink := NewUnassignedEventSink(prs, users, unassigned)
+	if sink.Name() == NewEventSink(prs, users, unassigned).Name() {
+		t.Fatalf("both sinks are named %q", sink.Name())
+	}
+	if err := sink.Handle(context.Background(), e); err != nil {
+		t.Fatalf("unassigned sink: %v", err)
+	}
+	if len(unassigned.sent) != 1 {
+		t.Fatalf("unassigned sink sent %d messages, want 1", len(unassigned.sent))
+	}
+	m := unassigned.sent[0]
+	if m.Kind != KindUnassigned || m.Recipient.ID != "u3" || m.Actor == nil || m.Actor.ID != "u4" {
+		t.Fatalf("unassigned message = %+v, want u3 told about u4's change", m)
+	}
+	if got, want := SlackText(m), "Alice: You were unassigned from pr-1 'Fix' by Carol, Bob takes over"; got != want {
+		t.Fatalf("text = %q, want %q", got, want)
+	}
+}
+
+func TestUnassignedEventSinkIgnoresOtherEvents(t *testing.T) {
+	n := &recordingNotifier{err: errors.New("must not be called")}
+	sink := NewUnassignedEventSink(prStub{}, userStub{}, n)
+
+	for _, typ := range []domain.EventType{domain.EventReviewerAssigned, domain.EventPRMerged} {
+		if err := sink.Handle(context.Background(), domain.OutboxEvent{Type: typ, Payload: []byte(`{}`)}); err != nil {
+			t.Errorf("%s: %v", typ, err)
+		}
+	}
+}
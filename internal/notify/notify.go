@@ -0,0 +1,89 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+type Kind string
+
+const (
+	KindAssigned   Kind = "assigned"
+	KindUnassigned Kind = "unassigned"
+	KindMerged     Kind = "merged"
+	KindReminder   Kind = "reminder"
+)
+
+// Message is a notification for one recipient about one pull request.
+// Counterpart is the other reviewer of a reassignment: the one replaced
+// for KindAssigned, the replacement for KindUnassigned. Actor is who made
+// the change, when known. Since is when the recipient was assigned; it is
+// set for reminders.
+type Message struct {
+	Kind        Kind
+	Recipient   domain.User
+	PR          domain.PullRequest
+	Author      domain.User
+	Counterpart *domain.User
+	Actor       *domain.User
+	Since       time.Time
+}
+
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, m Message) error
+}
+
+// LogNotifier writes notifications to the log instead of sending them.
+type LogNotifier struct {
+	logger *slog.Logger
+}
+
+func NewLogNotifier(logger *slog.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+func (n *LogNotifier) Name() string { return "log" }
+
+func (n *LogNotifier) Notify(_ context.Context, m Message) error {
+	n.logger.Info("notification",
+		slog.String("kind", string(m.Kind)),
+		slog.String("user_id", string(m.Recipient.ID)),
+		slog.String("pr_id", string(m.PR.ID)),
+	)
+	return nil
+}
+
+// Multi sends every message through all notifiers. When some of them
+// deliver the message and others fail, the error is a *PartialError.
+type Multi []Notifier
+
+func (m Multi) Name() string { return "multi" }
+
+func (m Multi) Notify(ctx context.Context, msg Message) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.Notify(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+		}
+	}
+	if len(errs) > 0 && len(errs) < len(m) {
+		return &PartialError{Err: errors.Join(errs...)}
+	}
+	return errors.Join(errs...)
+}
+
+// PartialError reports that the message reached at least one channel of a
+// Multi, so sending it again would duplicate it there.
+type PartialError struct {
+	Err error
+}
+
+func (e *PartialError) Error() string { return "partially sent: " + e.Err.Error() }
+
+func (e *PartialError) Unwrap() error { return e.Err }
@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type stubNotifier struct {
+	err error
+}
+
+func (n stubNotifier) Name() string { return "stub" }
+
+func (n stubNotifier) Notify(context.Context, Message) error { return n.err }
+
+func TestMultiReportsPartialDelivery(t *testing.T) {
+	down := errors.New("down")
+
+	tests := []struct {
+		name        string
+		notifiers   Multi
+		wantErr     bool
+		wantPartial bool
+	}{
+		{"all sent", Multi{stubNotifier{}, stubNotifier{}}, false, false},
+		{"one failed", Multi{stubNotifier{}, stubNotifier{err: down}}, true, true},
+		{"all failed", Multi{stubNotifier{err: down}, stubNotifier{err: down}}, true, false},
+	}
+
+	for _, tt := range tests {
+		err := tt.notifiers.Notify(context.Background(), Message{})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Notify = %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		var partial *PartialError
+		if errors.As(err, &partial) != tt.wantPartial {
+			t.Errorf("%s: Notify = %v, want partial %v", tt.name, err, tt.wantPartial)
+		}
+		if tt.wantErr && !errors.Is(err, down) {
+			t.Errorf("%s: Notify = %v, want it to wrap the channel error", tt.name, err)
+		}
+	}
+}
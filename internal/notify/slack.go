@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+type TeamSettingsGetter interface {
+	GetSettings(ctx context.Context, name domain.TeamName) (domain.TeamSettings, error)
+}
+
+// SlackNotifier posts messages to the incoming webhook of the recipient's
+// team. Teams without a configured webhook are skipped.
+type SlackNotifier struct {
+	settings TeamSettingsGetter
+	client   *http.Client
+}
+
+func NewSlackNotifier(settings TeamSettingsGetter, timeout time.Duration) *SlackNotifier {
+	return &SlackNotifier{
+		settings: settings,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+func (n *SlackNotifier) Name() string { return "slack" }
+
+func (n *SlackNotifier) Notify(ctx context.Context, m Message) error {
+	settings, err := n.settings.GetSettings(ctx, m.Recipient.TeamName)
+	if domain.IsDomainError(err, domain.ErrNotFound) {
+		// The team was deleted, so it has no channel.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if settings.SlackWebhookURL == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(map[string]string{"text": SlackText(m)})
+	if err != nil {
+		return fmt.Errorf("marshal slack message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, settings.SlackWebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post slack message: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SlackText renders the message, mentioning the recipient when their Slack
+// handle is known.
+func SlackText(m Message) string {
+	pr := fmt.Sprintf("%s '%s'", m.PR.ID, m.PR.Name)
+
+	var text string
+	switch m.Kind {
+	case KindAssigned:
+		text = fmt.Sprintf("You were assigned to review %s by %s", pr, m.Author.Username)
+		if m.Counterpart != nil {
+			text += fmt.Sprintf(" (replacing %s)", m.Counterpart.Username)
+		}
+	case KindUnassigned:
+		text = "You were unassigned from " + pr
+		if m.Actor != nil {
+			text += " by " + m.Actor.Username
+		}
+		if m.Counterpart != nil {
+			text += fmt.Sprintf(", %s takes over", m.Counterpart.Username)
+		}
+	case KindMerged:
+		text = fmt.Sprintf("Your pull request %s was merged", pr)
+	case KindReminder:
+		text = fmt.Sprintf("Reminder: %s by %s has been waiting for your review since %s", pr, m.Author.Username, m.Since.Format("2006-01-02 15:04 MST"))
+	default:
+		text = fmt.Sprintf("Update on %s", pr)
+	}
+
+	return mention(m.Recipient) + " " + text
+}
+
+func mention(u domain.User) string {
+	if u.SlackHandle != "" {
+		return "<@" + u.SlackHandle + ">"
+	}
+	return u.Username + ":"
+}
@@ -0,0 +1,94 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+type settingsStub map[domain.TeamName]domain.TeamSettings
+
+func (s settingsStub) GetSettings(_ context.Context, name domain.TeamName) (domain.TeamSettings, error) {
+	settings, ok := s[name]
+	if !ok {
+		return domain.TeamSettings{}, domain.NewDomainError(domain.ErrNotFound, "team not found")
+	}
+	return settings, nil
+}
+
+func TestSlackNotifierPostsToTeamWebhook(t *testing.T) {
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	n := NewSlackNotifier(settingsStub{"backend": {SlackWebhookURL: srv.URL}}, time.Second)
+
+	err := n.Notify(context.Background(), Message{
+		Kind:      KindAssigned,
+		Recipient: domain.User{ID: "u2", Username: "Bob", TeamName: "backend", SlackHandle: "U02BOB"},
+		PR:        domain.PullRequest{ID: "pr-1001", Name: "Add search"},
+		Author:    domain.User{ID: "u1", Username: "Egor"},
+	})
+	if err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+
+	want := "<@U02BOB> You were assigned to review pr-1001 'Add search' by Egor"
+	if got["text"] != want {
+		t.Fatalf("text = %q, want %q", got["text"], want)
+	}
+}
+
+func TestSlackNotifierSkipsTeamsWithoutWebhook(t *testing.T) {
+	n := NewSlackNotifier(settingsStub{"backend": {}}, time.Second)
+
+	err := n.Notify(context.Background(), Message{Kind: KindMerged, Recipient: domain.User{TeamName: "backend"}})
+	if err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+}
+
+func TestSlackNotifierFailsOnErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	n := NewSlackNotifier(settingsStub{"backend": {SlackWebhookURL: srv.URL}}, time.Second)
+
+	err := n.Notify(context.Background(), Message{Kind: KindMerged, Recipient: domain.User{TeamName: "backend"}})
+	if err == nil {
+		t.Fatal("expected an error for a 500 response")
+	}
+}
+
+func TestSlackNotifierSkipsDeletedTeams(t *testing.T) {
+	n := NewSlackNotifier(settingsStub{}, time.Second)
+
+	err := n.Notify(context.Background(), Message{Kind: KindMerged, Recipient: domain.User{TeamName: "gone"}})
+	if err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+}
+
+func TestSlackUnassignedTextWithoutActor(t *testing.T) {
+	got := SlackText(Message{
+		Kind:        KindUnassigned,
+		Recipient:   domain.User{Username: "Alice"},
+		PR:          domain.PullRequest{ID: "pr-1", Name: "Fix"},
+		Author:      domain.User{Username: "Egor"},
+		Counterpart: &domain.User{Username: "Bob"},
+	})
+	if want := "Alice: You were unassigned from pr-1 'Fix', Bob takes over"; got != want {
+		t.Fatalf("text = %q, want %q", got, want)
+	}
+}
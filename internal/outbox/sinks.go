@@ -0,0 +1,127 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+// LogSink writes every event to the log.
+type LogSink struct {
+	logger *slog.Logger
+}
+
+func NewLogSink(logger *slog.Logger) *LogSink {
+	return &LogSink{logger: logger}
+}
+
+func (s *LogSink) Name() string { return "log" }
+
+func (s *LogSink) Handle(_ context.Context, e domain.OutboxEvent) error {
+	s.logger.Info("domain event",
+		slog.Int64("event_id", e.ID),
+		slog.String("event_type", string(e.Type)),
+		slog.String("payload", string(e.Payload)),
+	)
+	return nil
+}
+
+// MemorySink keeps events in memory; meant for tests.
+type MemorySink struct {
+	mu     sync.Mutex
+	events []domain.OutboxEvent
+}
+
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (s *MemorySink) Name() string { return "memory" }
+
+func (s *MemorySink) Handle(_ context.Context, e domain.OutboxEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, e)
+	return nil
+}
+
+// Events returns a copy of the events received so far.
+func (s *MemorySink) Events() []domain.OutboxEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]domain.OutboxEvent(nil), s.events...)
+}
+
+type DeliveryEnqueuer interface {
+	EnqueueDeliveries(ctx context.Context, e domain.OutboxEvent) (int, error)
+}
+
+// WebhookSink turns events into deliveries for matching webhook
+// subscriptions; the webhook worker sends them.
+type WebhookSink struct {
+	deliveries DeliveryEnqueuer
+}
+
+func NewWebhookSink(deliveries DeliveryEnqueuer) *WebhookSink {
+	return &WebhookSink{deliveries: deliveries}
+}
+
+func (s *WebhookSink) Name() string { return "webhook" }
+
+func (s *WebhookSink) Handle(ctx context.Context, e domain.OutboxEvent) error {
+	_, err := s.deliveries.EnqueueDeliveries(ctx, e)
+	return err
+}
+
+type UserGetter interface {
+	GetUserByID(ctx context.Context, id domain.UserID) (domain.User, error)
+}
+
+type BacklogFiller interface {
+	FillBacklog(ctx context.Context, team domain.TeamName) (int, error)
+}
+
+// BacklogSink retries the assignment backlog of a team whenever one of its
+// members becomes active, including members newly added to the team.
+type BacklogSink struct {
+	users   UserGetter
+	backlog BacklogFiller
+}
+
+func NewBacklogSink(users UserGetter, backlog BacklogFiller) *BacklogSink {
+	return &BacklogSink{users: users, backlog: backlog}
+}
+
+func (s *BacklogSink) Name() string { return "backlog" }
+
+func (s *BacklogSink) Handle(ctx context.Context, e domain.OutboxEvent) error {
+	if e.Type != domain.EventUserActivityChanged {
+		return nil
+	}
+
+	var p struct {
+		UserID   string `json:"user_id"`
+		IsActive bool   `json:"is_active"`
+	}
+	if err := json.Unmarshal(e.Payload, &p); err != nil {
+		return fmt.Errorf("decode %s payload: %w", e.Type, err)
+	}
+	if !p.IsActive {
+		return nil
+	}
+
+	user, err := s.users.GetUserByID(ctx, domain.UserID(p.UserID))
+	if domain.IsDomainError(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = s.backlog.FillBacklog(ctx, user.TeamName)
+	return err
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+const absenceColumns = "id, user_id, starts_at, ends_at, COALESCE(reason, ''), reassign_reviews, reassigned_at, created_at"
+
+type AbsenceRepo struct {
+	db *sql.DB
+}
+
+func NewAbsenceRepo(db *sql.DB) *AbsenceRepo {
+	return &AbsenceRepo{db: db}
+}
+
+func scanAbsence(row rowScanner) (domain.Absence, error) {
+	var (
+		a            domain.Absence
+		userID       string
+		reassignedAt sql.NullTime
+	)
+
+	if err := row.Scan(&a.ID, &userID, &a.StartsAt, &a.EndsAt, &a.Reason, &a.ReassignReviews, &reassignedAt, &a.CreatedAt); err != nil {
+		return domain.Absence{}, err
+	}
+
+	a.UserID = domain.UserID(userID)
+	if reassignedAt.Valid {
+		t := reassignedAt.Time
+		a.ReassignedAt = &t
+	}
+	return a, nil
+}
+
+func (r *AbsenceRepo) CreateAbsence(ctx context.Context, a domain.Absence) (domain.Absence, error) {
+	query := "INSERT INTO user_absences (user_id, starts_at, ends_at, reason, reassign_reviews) VALUES ($1, $2, $3, $4, $5) RETURNING " + absenceColumns
+
+	created, err := scanAbsence(r.db.QueryRowContext(ctx, query, string(a.UserID), a.StartsAt, a.EndsAt, nullString(a.Reason), a.ReassignReviews))
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domain.Absence{}, domain.NewDomainError(domain.ErrNotFound, "user not found")
+		}
+		return domain.Absence{}, fmt.Errorf("create absence: %w", err)
+	}
+	return created, nil
+}
+
+// ListAbsences returns the user's absences that have not ended by now.
+func (r *AbsenceRepo) ListAbsences(ctx context.Context, userID domain.UserID, now time.Time) ([]domain.Absence, error) {
+	query := "SELECT " + absenceColumns + " FROM user_absences WHERE user_id = $1 AND ends_at > $2 ORDER BY starts_at, id"
+
+	return r.list(ctx, query, string(userID), now)
+}
+
+// ListStartedForReassign returns absences in progress at now whose open
+// reviews still have to be handed over, skipping those postponed past now.
+func (r *AbsenceRepo) ListStartedForReassign(ctx context.Context, now time.Time, limit int) ([]domain.Absence, error) {
+	query := "SELECT " + absenceColumns + " FROM user_absences WHERE reassign_reviews AND reassigned_at IS NULL AND starts_at <= $1 AND ends_at > $1 AND (reassign_retry_at IS NULL OR reassign_retry_at <= $1) ORDER BY starts_at, id LIMIT $2"
+
+	return r.list(ctx, query, now, limit)
+}
+
+func (r *AbsenceRepo) list(ctx context.Context, query string, args ...any) ([]domain.Absence, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list absences: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.Absence
+
+	for rows.Next() {
+		a, err := scanAbsence(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan absence: %w", err)
+		}
+		result = append(result, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate absences: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *AbsenceRepo) MarkReassigned(ctx context.Context, id int64, at time.Time) error {
+	if _, err := r.db.ExecContext(ctx, "UPDATE user_absences SET reassigned_at = $2 WHERE id = $1", id, at); err != nil {
+		return fmt.Errorf("mark absence reassigned: %w", err)
+	}
+	return nil
+}
+
+// PostponeReassign hides the absence from ListStartedForReassign until the
+// given time.
+func (r *AbsenceRepo) PostponeReassign(ctx context.Context, id int64, until time.Time) error {
+	if _, err := r.db.ExecContext(ctx, "UPDATE user_absences SET reassign_retry_at = $2 WHERE id = $1", id, until); err != nil {
+		return fmt.Errorf("postpone absence reassign: %w", err)
+	}
+	return nil
+}
+
+func (r *AbsenceRepo) DeleteAbsence(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM user_absences WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("delete absence: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete absence rows affected: %w", err)
+	}
+	if n == 0 {
+		return domain.NewDomainError(domain.ErrNotFound, "absence not found")
+	}
+	return nil
+}
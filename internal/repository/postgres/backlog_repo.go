@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+type BacklogRepo struct {
+	db *sql.DB
+}
+
+func NewBacklogRepo(db *sql.DB) *BacklogRepo {
+	return &BacklogRepo{db: db}
+}
+
+// Enqueue records how many reviewers the PR still misses.
+func (r *BacklogRepo) Enqueue(ctx context.Context, prID domain.PullRequestID, missing int) error {
+	const query = "INSERT INTO assignment_backlog (pull_request_id, missing) VALUES ($1, $2) ON CONFLICT (pull_request_id) DO UPDATE SET missing = EXCLUDED.missing"
+
+	if _, err := r.db.ExecContext(ctx, query, string(prID), missing); err != nil {
+		return fmt.Errorf("enqueue backlog entry: %w", err)
+	}
+	return nil
+}
+
+// ListBacklog returns open PRs still missing reviewers, oldest first. An
+// empty team lists every team.
+func (r *BacklogRepo) ListBacklog(ctx context.Context, team domain.TeamName) ([]domain.BacklogEntry, error) {
+	const query = "SELECT b.pull_request_id, pr.pull_request_name, pr.author_id, u.team_name, COALESCE((SELECT array_to_string(array_agg(prr.reviewer_id ORDER BY prr.reviewer_id), ',') FROM pull_request_reviewers prr WHERE prr.pull_request_id = b.pull_request_id), ''), b.missing, b.created_at, b.last_attempt_at FROM assignment_backlog b JOIN pull_requests pr ON pr.pull_request_id = b.pull_request_id JOIN users u ON u.user_id = pr.author_id WHERE pr.status = 'OPEN' AND ($1 = '' OR u.team_name = $1) ORDER BY b.created_at, b.pull_request_id"
+
+	rows, err := r.db.QueryContext(ctx, query, string(team))
+	if err != nil {
+		return nil, fmt.Errorf("list backlog: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.BacklogEntry
+
+	for rows.Next() {
+		var (
+			e                                   domain.BacklogEntry
+			prID, authorID, teamName, reviewers string
+			lastAttemptAt                       sql.NullTime
+		)
+
+		if err := rows.Scan(&prID, &e.Name, &authorID, &teamName, &reviewers, &e.Missing, &e.CreatedAt, &lastAttemptAt); err != nil {
+			return nil, fmt.Errorf("scan backlog entry: %w", err)
+		}
+
+		e.PullRequestID = domain.PullRequestID(prID)
+		e.AuthorID = domain.UserID(authorID)
+		e.TeamName = domain.TeamName(teamName)
+		if reviewers != "" {
+			for _, id := range strings.Split(reviewers, ",") {
+				e.AssignedReviewers = append(e.AssignedReviewers, domain.UserID(id))
+			}
+		}
+		if lastAttemptAt.Valid {
+			t := lastAttemptAt.Time
+			e.LastAttemptAt = &t
+		}
+
+		result = append(result, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate backlog: %w", err)
+	}
+
+	return result, nil
+}
+
+// FillBacklog assigns reviewers to a PR from the backlog and drops it from
+// there once no slots are missing. The backlog row is locked, so concurrent
+// fills take turns: reviewers already on the PR are skipped and the rest are
+// cut to the slots still missing. It returns the reviewers actually assigned.
+func (r *BacklogRepo) FillBacklog(ctx context.Context, prID domain.PullRequestID, reviewers []domain.UserID) (assigned []domain.UserID, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var missing int
+	err = tx.QueryRowContext(ctx, "SELECT missing FROM assignment_backlog WHERE pull_request_id = $1 FOR UPDATE", string(prID)).Scan(&missing)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Filled up meanwhile.
+		return nil, tx.Rollback()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lock backlog entry: %w", err)
+	}
+
+	current, _, err := loadReviewers(ctx, tx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range reviewers {
+		if len(assigned) == missing {
+			break
+		}
+		if !slices.Contains(current, id) && !slices.Contains(assigned, id) {
+			assigned = append(assigned, id)
+		}
+	}
+	if len(assigned) == 0 {
+		return nil, tx.Rollback()
+	}
+
+	if err = insertReviewers(ctx, tx, prID, assigned, domain.AssignmentReasonBacklog, ""); err != nil {
+		return nil, err
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM assignment_backlog WHERE pull_request_id = $1 AND missing <= $2", string(prID), len(assigned)); err != nil {
+		return nil, fmt.Errorf("delete backlog entry: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE assignment_backlog SET missing = missing - $2, last_attempt_at = now() WHERE pull_request_id = $1", string(prID), len(assigned)); err != nil {
+		return nil, fmt.Errorf("update backlog entry: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return assigned, nil
+}
+
+func (r *BacklogRepo) MarkBacklogAttempt(ctx context.Context, prID domain.PullRequestID) error {
+	if _, err := r.db.ExecContext(ctx, "UPDATE assignment_backlog SET last_attempt_at = now() WHERE pull_request_id = $1", string(prID)); err != nil {
+		return fmt.Errorf("mark backlog attempt: %w", err)
+	}
+	return nil
+}
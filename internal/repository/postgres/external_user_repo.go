@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+type ExternalUserRepo struct {
+	db *sql.DB
+}
+
+func NewExternalUserRepo(db *sql.DB) *ExternalUserRepo {
+	return &ExternalUserRepo{db: db}
+}
+
+func (r *ExternalUserRepo) UpsertMappings(ctx context.Context, mappings []domain.ExternalUserMapping) (err error) {
+	const query = "INSERT INTO external_user_mappings (provider, login, external_id, user_id) VALUES ($1, $2, $3, $4) ON CONFLICT (provider, login) DO UPDATE SET external_id = EXCLUDED.external_id, user_id = EXCLUDED.user_id"
+
+	if len(mappings) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	for _, m := range mappings {
+		externalID := sql.NullInt64{Int64: m.ExternalID, Valid: m.ExternalID != 0}
+		if _, err = tx.ExecContext(ctx, query, string(m.Provider), m.Login, externalID, string(m.UserID)); err != nil {
+			if isForeignKeyViolation(err) {
+				return domain.NewDomainError(domain.ErrNotFound, fmt.Sprintf("user %s not found", m.UserID))
+			}
+			if isUnique(err) {
+				return domain.NewValidationError("external_id", fmt.Sprintf("%d is already mapped to another login", m.ExternalID))
+			}
+			return fmt.Errorf("upsert mapping %s/%s: %w", m.Provider, m.Login, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+func (r *ExternalUserRepo) ListMappings(ctx context.Context, provider domain.ExternalProvider) ([]domain.ExternalUserMapping, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT provider, login, external_id, user_id FROM external_user_mappings WHERE provider = $1 ORDER BY login", string(provider))
+	if err != nil {
+		return nil, fmt.Errorf("list mappings: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.ExternalUserMapping
+
+	for rows.Next() {
+		var (
+			p, login, userID string
+			externalID       sql.NullInt64
+		)
+		if err := rows.Scan(&p, &login, &externalID, &userID); err != nil {
+			return nil, fmt.Errorf("scan mapping: %w", err)
+		}
+		result = append(result, domain.ExternalUserMapping{
+			Provider:   domain.ExternalProvider(p),
+			Login:      login,
+			ExternalID: externalID.Int64,
+			UserID:     domain.UserID(userID),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate mappings: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *ExternalUserRepo) ResolveLogin(ctx context.Context, provider domain.ExternalProvider, login string) (domain.UserID, error) {
+	var userID string
+
+	err := r.db.QueryRowContext(ctx, "SELECT user_id FROM external_user_mappings WHERE provider = $1 AND login = $2", string(provider), login).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.NewDomainError(domain.ErrNotFound, fmt.Sprintf("%s login %s is not mapped to a user", provider, login))
+		}
+		return "", fmt.Errorf("resolve login: %w", err)
+	}
+
+	return domain.UserID(userID), nil
+}
+
+func (r *ExternalUserRepo) ResolveExternalID(ctx context.Context, provider domain.ExternalProvider, externalID int64) (domain.UserID, error) {
+	var userID string
+
+	err := r.db.QueryRowContext(ctx, "SELECT user_id FROM external_user_mappings WHERE provider = $1 AND external_id = $2", string(provider), externalID).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.NewDomainError(domain.ErrNotFound, fmt.Sprintf("%s user id %d is not mapped to a user", provider, externalID))
+		}
+		return "", fmt.Errorf("resolve external id: %w", err)
+	}
+
+	return domain.UserID(userID), nil
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+type eventPayload struct {
+	Event         domain.EventType `json:"event"`
+	PullRequestID string           `json:"pull_request_id,omitempty"`
+	Actor         string           `json:"actor,omitempty"`
+	UserID        string           `json:"user_id,omitempty"`
+	OldUserID     string           `json:"old_user_id,omitempty"`
+	IsActive      *bool            `json:"is_active,omitempty"`
+	Details       string           `json:"details,omitempty"`
+	OccurredAt    time.Time        `json:"occurred_at"`
+}
+
+// insertOutbox stores the event in the caller's transaction, so it is
+// published exactly when the change that caused it is committed.
+func insertOutbox(ctx context.Context, tx *sql.Tx, p eventPayload) error {
+	const query = "INSERT INTO outbox (event_type, payload) VALUES ($1, $2::jsonb)"
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("marshal %s payload: %w", p.Event, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, query, string(p.Event), string(body)); err != nil {
+		return fmt.Errorf("insert %s into outbox: %w", p.Event, err)
+	}
+	return nil
+}
+
+type OutboxRepo struct {
+	db *sql.DB
+}
+
+func NewOutboxRepo(db *sql.DB) *OutboxRepo {
+	return &OutboxRepo{db: db}
+}
+
+// ClaimDue picks undispatched, live events that are due, oldest first, and
+// pushes their next attempt back by lease so a dispatcher that dies while
+// handling them does not hold them forever.
+func (r *OutboxRepo) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]domain.OutboxEvent, error) {
+	const query = "WITH due AS (SELECT id FROM outbox WHERE dispatched_at IS NULL AND dead_at IS NULL AND next_attempt_at <= now() ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED) " +
+		"UPDATE outbox o SET attempts = o.attempts + 1, next_attempt_at = now() + make_interval(secs => $2) FROM due WHERE o.id = due.id " +
+		"RETURNING o.id, o.event_type, o.payload, o.attempts, array_to_string(o.delivered_sinks, ','), o.created_at"
+
+	rows, err := r.db.QueryContext(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.OutboxEvent
+
+	for rows.Next() {
+		var (
+			e                    domain.OutboxEvent
+			eventType, delivered string
+		)
+		if err := rows.Scan(&e.ID, &eventType, &e.Payload, &e.Attempts, &delivered, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan outbox event: %w", err)
+		}
+		e.Type = domain.EventType(eventType)
+		e.DeliveredSinks = splitTags(delivered)
+		result = append(result, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate outbox events: %w", err)
+	}
+
+	// UPDATE ... RETURNING does not keep the CTE order.
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+
+	return result, nil
+}
+
+func (r *OutboxRepo) MarkDispatched(ctx context.Context, id int64) error {
+	if _, err := r.db.ExecContext(ctx, "UPDATE outbox SET dispatched_at = now(), last_error = NULL WHERE id = $1", id); err != nil {
+		return fmt.Errorf("mark outbox event %d dispatched: %w", id, err)
+	}
+	return nil
+}
+
+// MarkSinkDelivered records that sink accepted the event, so later attempts
+// skip it.
+func (r *OutboxRepo) MarkSinkDelivered(ctx context.Context, id int64, sink string) error {
+	const query = "UPDATE outbox SET delivered_sinks = array_append(delivered_sinks, $2) WHERE id = $1 AND NOT ($2 = ANY(delivered_sinks))"
+
+	if _, err := r.db.ExecContext(ctx, query, id, sink); err != nil {
+		return fmt.Errorf("mark outbox event %d delivered to %s: %w", id, sink, err)
+	}
+	return nil
+}
+
+// MarkFailed schedules the next attempt at retryAt, or marks the event dead
+// when retryAt is nil.
+func (r *OutboxRepo) MarkFailed(ctx context.Context, id int64, errMsg string, retryAt *time.Time) error {
+	const query = "UPDATE outbox SET last_error = $2, next_attempt_at = COALESCE($3::timestamptz, next_attempt_at), dead_at = CASE WHEN $3::timestamptz IS NULL THEN now() END WHERE id = $1"
+
+	if _, err := r.db.ExecContext(ctx, query, id, errMsg, retryAt); err != nil {
+		return fmt.Errorf("mark outbox event %d failed: %w", id, err)
+	}
+	return nil
+}
+
+// PurgeDispatched deletes events dispatched before the given time. Dead
+// events are kept for inspection.
+func (r *OutboxRepo) PurgeDispatched(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM outbox WHERE dispatched_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("purge outbox: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("rows affected: %w", err)
+	}
+	return n, nil
+}
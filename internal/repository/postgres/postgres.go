@@ -23,10 +23,61 @@ func isUnique(err error) bool {
 	return false
 }
 
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "23503"
+	}
+
+	msg := err.Error()
+	if strings.Contains(msg, "SQLSTATE 23503") ||
+		strings.Contains(msg, "violates foreign key constraint") {
+		return true
+	}
+
+	return false
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullInt(n *int) sql.NullInt64 {
+	if n == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*n), Valid: true}
+}
+
+func intPtr(n sql.NullInt64) *int {
+	if !n.Valid {
+		return nil
+	}
+	v := int(n.Int64)
+	return &v
+}
+
 type DB struct {
 	Conn *sql.DB
 }
 
+// splitTags parses tags selected with array_to_string(column, ','); tags
+// never contain commas.
+func splitTags(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// tagList keeps an empty tag list from being sent as NULL.
+func tagList(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
 func NewDB(conn *sql.DB) *DB {
 	return &DB{Conn: conn}
 }
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+func insertEvent(ctx context.Context, tx *sql.Tx, e domain.PREvent) error {
+	const query = "INSERT INTO pr_events (pull_request_id, event_type, actor, reviewer_id, old_reviewer_id, details) VALUES ($1, $2, $3, $4, $5, $6) RETURNING created_at"
+
+	err := tx.QueryRowContext(ctx, query, string(e.PullRequestID), string(e.Type), nullString(string(e.Actor)), nullString(string(e.ReviewerID)), nullString(string(e.OldReviewerID)), nullString(e.Details)).Scan(&e.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert %s event: %w", e.Type, err)
+	}
+
+	eventType, ok := domain.EventForPREvent(e.Type)
+	if !ok {
+		return nil
+	}
+
+	return insertOutbox(ctx, tx, eventPayload{
+		Event:         eventType,
+		PullRequestID: string(e.PullRequestID),
+		Actor:         string(e.Actor),
+		UserID:        string(e.ReviewerID),
+		OldUserID:     string(e.OldReviewerID),
+		Details:       e.Details,
+		OccurredAt:    e.CreatedAt.UTC(),
+	})
+}
+
+func (r *PRRepo) ListEvents(ctx context.Context, prID domain.PullRequestID) ([]domain.PREvent, error) {
+	const query = "SELECT id, pull_request_id, event_type, actor, reviewer_id, old_reviewer_id, details, created_at FROM pr_events WHERE pull_request_id = $1 ORDER BY created_at, id"
+
+	rows, err := r.db.QueryContext(ctx, query, string(prID))
+	if err != nil {
+		return nil, fmt.Errorf("list pr events: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.PREvent
+
+	for rows.Next() {
+		var (
+			e                                         domain.PREvent
+			id, eventType                             string
+			actor, reviewerID, oldReviewerID, details sql.NullString
+		)
+
+		if err := rows.Scan(&e.ID, &id, &eventType, &actor, &reviewerID, &oldReviewerID, &details, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan pr event: %w", err)
+		}
+
+		e.PullRequestID = domain.PullRequestID(id)
+		e.Type = domain.PREventType(eventType)
+		e.Actor = domain.UserID(actor.String)
+		e.ReviewerID = domain.UserID(reviewerID.String)
+		e.OldReviewerID = domain.UserID(oldReviewerID.String)
+		e.Details = details.String
+
+		result = append(result, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate pr events: %w", err)
+	}
+
+	return result, nil
+}
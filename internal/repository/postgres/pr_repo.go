@@ -36,7 +36,7 @@ func (r *PRRepo) Create(ctx context.Context, pr domain.PullRequest) (err error)
 		}
 	}()
 
-	_, err = tx.ExecContext(ctx, "INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status) VALUES ($1, $2, $3, $4)", string(pr.ID), pr.Name, string(pr.AuthorID), string(pr.Status))
+	_, err = tx.ExecContext(ctx, "INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, labels) VALUES ($1, $2, $3, $4, $5::text[])", string(pr.ID), pr.Name, string(pr.AuthorID), string(pr.Status), tagList(pr.Labels))
 	if err != nil {
 		if isUnique(err) {
 			return domain.NewDomainError(domain.ErrPRExists, "pull request already exists")
@@ -44,13 +44,68 @@ func (r *PRRepo) Create(ctx context.Context, pr domain.PullRequest) (err error)
 		return fmt.Errorf("insert pull_request: %w", err)
 	}
 
-	if len(pr.AssignedReviewers) > 0 {
-		const insertReviewer = "INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id) VALUES ($1, $2)"
+	if err = insertEvent(ctx, tx, domain.PREvent{PullRequestID: pr.ID, Type: domain.PREventCreated, Actor: pr.AuthorID}); err != nil {
+		return err
+	}
+
+	if err = insertReviewers(ctx, tx, pr.ID, pr.AssignedReviewers, domain.AssignmentReasonInitial, pr.AuthorID); err != nil {
+		return err
+	}
+
+	for _, path := range pr.ChangedFiles {
+		_, err = tx.ExecContext(ctx, "INSERT INTO pull_request_files (pull_request_id, path) VALUES ($1, $2) ON CONFLICT DO NOTHING", string(pr.ID), path)
+		if err != nil {
+			return fmt.Errorf("insert changed file: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetChangedFiles returns the paths the PR touches, as given on creation.
+func (r *PRRepo) GetChangedFiles(ctx context.Context, id domain.PullRequestID) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT path FROM pull_request_files WHERE pull_request_id = $1 ORDER BY path", string(id))
+	if err != nil {
+		return nil, fmt.Errorf("get changed files: %w", err)
+	}
+	defer rows.Close()
+
+	var files []string
+
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, fmt.Errorf("scan changed file: %w", err)
+		}
+		files = append(files, path)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate changed files: %w", err)
+	}
+
+	return files, nil
+}
+
+func insertReviewers(ctx context.Context, tx *sql.Tx, prID domain.PullRequestID, reviewers []domain.UserID, reason domain.AssignmentReason, actor domain.UserID) error {
+	const (
+		insertReviewer   = "INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id) VALUES ($1, $2)"
+		insertAssignment = "INSERT INTO review_assignments (pull_request_id, reviewer_id, reason, actor) VALUES ($1, $2, $3, $4)"
+	)
 
-		for _, reviewerID := range pr.AssignedReviewers {
-			_, err = tx.ExecContext(ctx, insertReviewer, string(pr.ID), string(reviewerID))
-			if err != nil {
-				return fmt.Errorf("insert reviewer %s: %w", reviewerID, err)
+	if err := checkReviewCapacity(ctx, tx, reviewers); err != nil {
+		return err
+	}
+
+	for _, reviewerID := range reviewers {
+		if _, err := tx.ExecContext(ctx, insertReviewer, string(prID), string(reviewerID)); err != nil {
+			return fmt.Errorf("insert reviewer %s: %w", reviewerID, err)
+		}
+		if _, err := tx.ExecContext(ctx, insertAssignment, string(prID), string(reviewerID), string(reason), nullString(string(actor))); err != nil {
+			return fmt.Errorf("insert assignment %s: %w", reviewerID, err)
+		}
+		if reason == domain.AssignmentReasonInitial || reason == domain.AssignmentReasonBacklog {
+			if err := insertEvent(ctx, tx, domain.PREvent{PullRequestID: prID, Type: domain.PREventReviewerAssigned, Actor: actor, ReviewerID: reviewerID}); err != nil {
+				return err
 			}
 		}
 	}
@@ -58,7 +113,7 @@ func (r *PRRepo) Create(ctx context.Context, pr domain.PullRequest) (err error)
 }
 
 func (r *PRRepo) GetByID(ctx context.Context, id domain.PullRequestID) (domain.PullRequest, error) {
-	const query = "SELECT p.pull_request_id, p.pull_request_name, p.author_id, p.status, p.created_at, p.merged_at, r.reviewer_id FROM pull_requests p LEFT JOIN pull_request_reviewers r ON r.pull_request_id = p.pull_request_id WHERE p.pull_request_id = $1"
+	const query = "SELECT p.pull_request_id, p.pull_request_name, p.author_id, p.status, array_to_string(p.labels, ','), p.created_at, p.merged_at, p.closed_at, r.reviewer_id, r.state, r.reviewed_at FROM pull_requests p LEFT JOIN pull_request_reviewers r ON r.pull_request_id = p.pull_request_id WHERE p.pull_request_id = $1"
 
 	rows, err := r.db.QueryContext(ctx, query, string(id))
 	if err != nil {
@@ -68,17 +123,19 @@ func (r *PRRepo) GetByID(ctx context.Context, id domain.PullRequestID) (domain.P
 
 	var pr domain.PullRequest
 	var reviewers []domain.UserID
+	var reviews []domain.Review
 	found := false
 
 	for rows.Next() {
 		var (
-			prID, name, authorID, status string
-			createdAt                    time.Time
-			mergedAt                     sql.NullTime
-			reviewerID                   sql.NullString
+			prID, name, authorID, status, labels string
+			createdAt                            time.Time
+			mergedAt, closedAt                   sql.NullTime
+			reviewerID, reviewState              sql.NullString
+			reviewedAt                           sql.NullTime
 		)
 
-		if err := rows.Scan(&prID, &name, &authorID, &status, &createdAt, &mergedAt, &reviewerID); err != nil {
+		if err := rows.Scan(&prID, &name, &authorID, &status, &labels, &createdAt, &mergedAt, &closedAt, &reviewerID, &reviewState, &reviewedAt); err != nil {
 			return domain.PullRequest{}, fmt.Errorf("scan pull_request row: %w", err)
 		}
 
@@ -87,6 +144,7 @@ func (r *PRRepo) GetByID(ctx context.Context, id domain.PullRequestID) (domain.P
 			pr.Name = name
 			pr.AuthorID = domain.UserID(authorID)
 			pr.Status = domain.PRStatus(status)
+			pr.Labels = splitTags(labels)
 
 			pr.CreatedAt = &createdAt
 
@@ -94,12 +152,17 @@ func (r *PRRepo) GetByID(ctx context.Context, id domain.PullRequestID) (domain.P
 				t := mergedAt.Time
 				pr.MergedAt = &t
 			}
+			if closedAt.Valid {
+				t := closedAt.Time
+				pr.ClosedAt = &t
+			}
 
 			found = true
 		}
 
 		if reviewerID.Valid {
 			reviewers = append(reviewers, domain.UserID(reviewerID.String))
+			reviews = append(reviews, newReview(reviewerID.String, reviewState.String, reviewedAt))
 		}
 	}
 
@@ -112,32 +175,88 @@ func (r *PRRepo) GetByID(ctx context.Context, id domain.PullRequestID) (domain.P
 	}
 
 	pr.AssignedReviewers = reviewers
+	pr.Reviews = reviews
 
 	return pr, nil
 }
 
-func (r *PRRepo) SetMerged(ctx context.Context, id domain.PullRequestID, mergedAt time.Time) (domain.PullRequest, error) {
-	const query = "UPDATE pull_requests SET status = 'MERGED', merged_at = COALESCE(merged_at, $2) WHERE pull_request_id = $1 RETURNING pull_request_id, pull_request_name, author_id, status, created_at, merged_at"
+// SetMerged merges the PR. With a policy the PR must be OPEN and meet it
+// under a lock on its row, so no review, reviewer change or close committed
+// after the service looked at the PR can slip past the check. Without one,
+// as for a merge that already happened elsewhere, only a merged PR is
+// refused.
+func (r *PRRepo) SetMerged(ctx context.Context, id domain.PullRequestID, mergedAt time.Time, actor domain.UserID, details string, policy *domain.TeamSettings) (pr domain.PullRequest, err error) {
+	const query = "UPDATE pull_requests SET status = 'MERGED', merged_at = COALESCE(merged_at, $2) WHERE pull_request_id = $1 AND status = $3 RETURNING pull_request_id, pull_request_name, author_id, status, array_to_string(labels, ','), created_at, merged_at, closed_at"
 	var (
-		prID, name, authorID, status string
-		createdAt                    time.Time
-		merged                       sql.NullTime
+		prID, name, authorID, status, labels string
+		createdAt                            time.Time
+		merged, closed                       sql.NullTime
 	)
 
-	err := r.db.QueryRowContext(ctx, query, string(id), mergedAt).Scan(&prID, &name, &authorID, &status, &createdAt, &merged)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("begin tx: %w", err)
+	}
 
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var prevStatus string
+	err = tx.QueryRowContext(ctx, "SELECT status FROM pull_requests WHERE pull_request_id = $1 FOR UPDATE", string(id)).Scan(&prevStatus)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.PullRequest{}, domain.NewDomainError(domain.ErrNotFound, "pull request not found")
 		}
+		return domain.PullRequest{}, fmt.Errorf("lock pull_request: %w", err)
+	}
+
+	if domain.PRStatus(prevStatus) == domain.PRStatusMerged {
+		return domain.PullRequest{}, domain.NewDomainError(domain.ErrPRMerged, "pull request is already merged")
+	}
+
+	if policy != nil {
+		if err = domain.PRStatus(prevStatus).RequireOpen("merge"); err != nil {
+			return domain.PullRequest{}, err
+		}
+
+		var reviews []domain.Review
+		if _, reviews, err = loadReviewers(ctx, tx, id); err != nil {
+			return domain.PullRequest{}, err
+		}
+		if blockers := policy.MergeBlockers(domain.PullRequest{ID: id, Reviews: reviews}); len(blockers) > 0 {
+			return domain.PullRequest{}, domain.NewMergeBlockedError(blockers)
+		}
+	}
+
+	err = tx.QueryRowContext(ctx, query, string(id), mergedAt, prevStatus).Scan(&prID, &name, &authorID, &status, &labels, &createdAt, &merged, &closed)
+	if err != nil {
 		return domain.PullRequest{}, fmt.Errorf("set merged: %w", err)
 	}
 
-	pr := domain.PullRequest{
-		ID:       domain.PullRequestID(prID),
-		Name:     name,
-		AuthorID: domain.UserID(authorID),
-		Status:   domain.PRStatus(status),
+	if err = insertEvent(ctx, tx, domain.PREvent{PullRequestID: id, Type: domain.PREventMerged, Actor: actor, Details: details}); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	reviewers, reviews, err := loadReviewers(ctx, tx, id)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return domain.PullRequest{}, fmt.Errorf("commit tx: %w", err)
+	}
+
+	pr = domain.PullRequest{
+		ID:                domain.PullRequestID(prID),
+		Name:              name,
+		AuthorID:          domain.UserID(authorID),
+		Status:            domain.PRStatus(status),
+		Labels:            splitTags(labels),
+		AssignedReviewers: reviewers,
+		Reviews:           reviews,
 	}
 
 	pr.CreatedAt = &createdAt
@@ -145,40 +264,246 @@ func (r *PRRepo) SetMerged(ctx context.Context, id domain.PullRequestID, mergedA
 		t := merged.Time
 		pr.MergedAt = &t
 	}
+	if closed.Valid {
+		t := closed.Time
+		pr.ClosedAt = &t
+	}
+
+	return pr, nil
+}
 
-	reviewers, err := r.loadReviewers(ctx, pr.ID)
+func (r *PRRepo) SetClosed(ctx context.Context, id domain.PullRequestID, closedAt time.Time, actor domain.UserID) (pr domain.PullRequest, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// A PR merged meanwhile, for example by a merge webhook, stays merged.
+	res, err := tx.ExecContext(ctx, "UPDATE pull_requests SET status = 'CLOSED', closed_at = $2 WHERE pull_request_id = $1 AND status IN ('OPEN', 'DRAFT')", string(id), closedAt)
+	if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("set closed: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("rows affected: %w", err)
+	}
+	if affected == 0 {
+		var status string
+		err = tx.QueryRowContext(ctx, "SELECT status FROM pull_requests WHERE pull_request_id = $1", string(id)).Scan(&status)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return domain.PullRequest{}, domain.NewDomainError(domain.ErrNotFound, "pull request not found")
+		case err != nil:
+			return domain.PullRequest{}, fmt.Errorf("get pull_request status: %w", err)
+		case domain.PRStatus(status) == domain.PRStatusMerged:
+			return domain.PullRequest{}, domain.NewDomainError(domain.ErrPRMerged, "cannot close merged PR")
+		}
+		// Closed meanwhile by somebody else; closing is idempotent.
+		if err = tx.Commit(); err != nil {
+			return domain.PullRequest{}, fmt.Errorf("commit tx: %w", err)
+		}
+		return r.GetByID(ctx, id)
+	}
+
+	if err = insertEvent(ctx, tx, domain.PREvent{PullRequestID: id, Type: domain.PREventClosed, Actor: actor}); err != nil {
 		return domain.PullRequest{}, err
 	}
-	pr.AssignedReviewers = reviewers
 
-	return pr, nil
+	if err = tx.Commit(); err != nil {
+		return domain.PullRequest{}, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+// checkReviewCapacity locks the reviewers' user rows and makes sure each of
+// them can take one more open review, so concurrent assignments cannot push
+// anybody past the limit the service checked before. The load is counted in
+// a separate statement to see reviews committed while waiting for the lock.
+func checkReviewCapacity(ctx context.Context, tx *sql.Tx, reviewers []domain.UserID) error {
+	if len(reviewers) == 0 {
+		return nil
+	}
+
+	const (
+		lockUsers = "SELECT user_id FROM users WHERE user_id = ANY($1::text[]) ORDER BY user_id FOR UPDATE"
+		overLimit = "SELECT u.user_id FROM users u LEFT JOIN team_settings s ON s.team_name = u.team_name WHERE u.user_id = ANY($1::text[]) AND COALESCE(u.max_open_reviews, s.max_open_reviews, 0) > 0 AND (SELECT COUNT(*) FROM pull_request_reviewers prr JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id WHERE prr.reviewer_id = u.user_id AND pr.status = 'OPEN') >= COALESCE(u.max_open_reviews, s.max_open_reviews, 0) ORDER BY u.user_id LIMIT 1"
+	)
+
+	ids := make([]string, 0, len(reviewers))
+	for _, id := range reviewers {
+		ids = append(ids, string(id))
+	}
+
+	if _, err := tx.ExecContext(ctx, lockUsers, ids); err != nil {
+		return fmt.Errorf("lock reviewers: %w", err)
+	}
+
+	var full string
+	err := tx.QueryRowContext(ctx, overLimit, ids).Scan(&full)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("check review capacity: %w", err)
+	}
+	return domain.NewDomainError(domain.ErrNoCandidate, fmt.Sprintf("reviewer %s is at review capacity", full))
+}
+
+func (r *PRRepo) SetOpen(ctx context.Context, id domain.PullRequestID, newReviewers []domain.UserID, actor domain.UserID) (pr domain.PullRequest, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var prevStatus string
+	err = tx.QueryRowContext(ctx, "SELECT status FROM pull_requests WHERE pull_request_id = $1 FOR UPDATE", string(id)).Scan(&prevStatus)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.PullRequest{}, domain.NewDomainError(domain.ErrNotFound, "pull request not found")
+		}
+		return domain.PullRequest{}, fmt.Errorf("lock pull_request: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE pull_requests SET status = 'OPEN', closed_at = NULL WHERE pull_request_id = $1", string(id)); err != nil {
+		return domain.PullRequest{}, fmt.Errorf("set open: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE pull_request_reviewers SET reminded_at = NULL, escalated_at = NULL, sla_retry_at = NULL WHERE pull_request_id = $1", string(id)); err != nil {
+		return domain.PullRequest{}, fmt.Errorf("reset review sla: %w", err)
+	}
+
+	eventType := domain.PREventReopened
+	if domain.PRStatus(prevStatus) == domain.PRStatusDraft {
+		eventType = domain.PREventReadyForReview
+	}
+	if err = insertEvent(ctx, tx, domain.PREvent{PullRequestID: id, Type: eventType, Actor: actor}); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	if err = insertReviewers(ctx, tx, id, newReviewers, domain.AssignmentReasonInitial, actor); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return domain.PullRequest{}, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return r.GetByID(ctx, id)
+}
 
+// queryer is a *sql.DB or a *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
 }
 
-func (r *PRRepo) loadReviewers(ctx context.Context, id domain.PullRequestID) ([]domain.UserID, error) {
-	rows, err := r.db.QueryContext(ctx, "SELECT reviewer_id FROM pull_request_reviewers WHERE pull_request_id = $1", string(id))
+func loadReviewers(ctx context.Context, q queryer, id domain.PullRequestID) ([]domain.UserID, []domain.Review, error) {
+	rows, err := q.QueryContext(ctx, "SELECT reviewer_id, state, reviewed_at FROM pull_request_reviewers WHERE pull_request_id = $1", string(id))
 	if err != nil {
-		return nil, fmt.Errorf("load reviewers: %w", err)
+		return nil, nil, fmt.Errorf("load reviewers: %w", err)
 	}
 	defer rows.Close()
 
 	var reviewers []domain.UserID
+	var reviews []domain.Review
 	for rows.Next() {
-		var rid string
-		if err := rows.Scan(&rid); err != nil {
-			return nil, fmt.Errorf("scan reviewer: %w", err)
+		var (
+			rid, state string
+			reviewedAt sql.NullTime
+		)
+		if err := rows.Scan(&rid, &state, &reviewedAt); err != nil {
+			return nil, nil, fmt.Errorf("scan reviewer: %w", err)
 		}
 		reviewers = append(reviewers, domain.UserID(rid))
+		reviews = append(reviews, newReview(rid, state, reviewedAt))
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterate reviewers: %w", err)
+		return nil, nil, fmt.Errorf("iterate reviewers: %w", err)
 	}
 
-	return reviewers, nil
+	return reviewers, reviews, nil
+}
+
+func newReview(reviewerID, state string, reviewedAt sql.NullTime) domain.Review {
+	review := domain.Review{
+		ReviewerID: domain.UserID(reviewerID),
+		State:      domain.ReviewState(state),
+	}
+	if reviewedAt.Valid {
+		t := reviewedAt.Time
+		review.ReviewedAt = &t
+	}
+	return review
 }
 
-func (r *PRRepo) ReplaceReviewer(ctx context.Context, prID domain.PullRequestID, oldReviewerID, newReviewerID domain.UserID) (pr domain.PullRequest, err error) {
+func (r *PRRepo) SetReviewState(ctx context.Context, prID domain.PullRequestID, reviewerID domain.UserID, state domain.ReviewState, reviewedAt time.Time) (pr domain.PullRequest, err error) {
+	if err := state.Validate(); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// The share lock keeps the PR from being merged or closed until the
+	// review is stored.
+	var status string
+	err = tx.QueryRowContext(ctx, "SELECT status FROM pull_requests WHERE pull_request_id = $1 FOR SHARE", string(prID)).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.PullRequest{}, domain.NewDomainError(domain.ErrNotFound, "pull request not found")
+		}
+		return domain.PullRequest{}, fmt.Errorf("lock pull_request: %w", err)
+	}
+	if err = domain.PRStatus(status).RequireOpen("review"); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	res, err := tx.ExecContext(ctx, "UPDATE pull_request_reviewers SET state = $1, reviewed_at = $2 WHERE pull_request_id = $3 AND reviewer_id = $4", string(state), reviewedAt, string(prID), string(reviewerID))
+	if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("set review state: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return domain.PullRequest{}, fmt.Errorf("rows affected: %w", err)
+	}
+	if affected == 0 {
+		return domain.PullRequest{}, domain.NewDomainError(domain.ErrNotAssigned, "reviewer is not assigned to this pull request")
+	}
+
+	if err = insertEvent(ctx, tx, domain.PREvent{PullRequestID: prID, Type: domain.PREventReviewSubmitted, Actor: reviewerID, ReviewerID: reviewerID, Details: string(state)}); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return domain.PullRequest{}, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return r.GetByID(ctx, prID)
+}
+
+func (r *PRRepo) ReplaceReviewer(ctx context.Context, prID domain.PullRequestID, oldReviewerID, newReviewerID domain.UserID, reason domain.AssignmentReason, actor domain.UserID) (pr domain.PullRequest, err error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 
 	if err != nil {
@@ -206,10 +531,18 @@ func (r *PRRepo) ReplaceReviewer(ctx context.Context, prID domain.PullRequestID,
 		return domain.PullRequest{}, domain.NewDomainError(domain.ErrNotAssigned, "reviewer is not assigned to this pull request")
 	}
 
-	_, err = tx.ExecContext(ctx, "INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id)VALUES ($1, $2)", string(prID), string(newReviewerID))
+	_, err = tx.ExecContext(ctx, "UPDATE review_assignments SET unassigned_at = now() WHERE pull_request_id = $1 AND reviewer_id = $2 AND unassigned_at IS NULL", string(prID), string(oldReviewerID))
 
 	if err != nil {
-		return domain.PullRequest{}, fmt.Errorf("insert new reviewer: %w", err)
+		return domain.PullRequest{}, fmt.Errorf("close old assignment: %w", err)
+	}
+
+	if err = insertReviewers(ctx, tx, prID, []domain.UserID{newReviewerID}, reason, actor); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	if err = insertEvent(ctx, tx, domain.PREvent{PullRequestID: prID, Type: domain.PREventReviewerReplaced, Actor: actor, ReviewerID: newReviewerID, OldReviewerID: oldReviewerID, Details: string(reason)}); err != nil {
+		return domain.PullRequest{}, err
 	}
 
 	if err = tx.Commit(); err != nil {
@@ -225,7 +558,7 @@ func (r *PRRepo) ReplaceReviewer(ctx context.Context, prID domain.PullRequestID,
 }
 
 func (r *PRRepo) ListByReviewer(ctx context.Context, reviewerID domain.UserID) ([]domain.PullRequest, error) {
-	const query = "SELECT p.pull_request_id, p.pull_request_name, p.author_id, p.status, p.created_at, p.merged_at FROM pull_requests p JOIN pull_request_reviewers r ON r.pull_request_id = p.pull_request_id WHERE r.reviewer_id = $1 ORDER BY p.created_at DESC, p.pull_request_id"
+	const query = "SELECT p.pull_request_id, p.pull_request_name, p.author_id, p.status, p.created_at, p.merged_at, p.closed_at, r.state, r.reviewed_at FROM pull_requests p JOIN pull_request_reviewers r ON r.pull_request_id = p.pull_request_id WHERE r.reviewer_id = $1 ORDER BY p.created_at DESC, p.pull_request_id"
 
 	rows, err := r.db.QueryContext(ctx, query, string(reviewerID))
 	if err != nil {
@@ -237,12 +570,12 @@ func (r *PRRepo) ListByReviewer(ctx context.Context, reviewerID domain.UserID) (
 
 	for rows.Next() {
 		var (
-			id, name, authorID, status string
-			createdAt                  time.Time
-			mergedAt                   sql.NullTime
+			id, name, authorID, status, state string
+			createdAt                         time.Time
+			mergedAt, closedAt, reviewedAt    sql.NullTime
 		)
 
-		if err := rows.Scan(&id, &name, &authorID, &status, &createdAt, &mergedAt); err != nil {
+		if err := rows.Scan(&id, &name, &authorID, &status, &createdAt, &mergedAt, &closedAt, &state, &reviewedAt); err != nil {
 			return nil, fmt.Errorf("scan PR: %w", err)
 		}
 
@@ -251,6 +584,7 @@ func (r *PRRepo) ListByReviewer(ctx context.Context, reviewerID domain.UserID) (
 			Name:     name,
 			AuthorID: domain.UserID(authorID),
 			Status:   domain.PRStatus(status),
+			Reviews:  []domain.Review{newReview(string(reviewerID), state, reviewedAt)},
 		}
 
 		pr.CreatedAt = &createdAt
@@ -258,6 +592,10 @@ func (r *PRRepo) ListByReviewer(ctx context.Context, reviewerID domain.UserID) (
 			t := mergedAt.Time
 			pr.MergedAt = &t
 		}
+		if closedAt.Valid {
+			t := closedAt.Time
+			pr.ClosedAt = &t
+		}
 
 		result = append(result, pr)
 	}
@@ -294,3 +632,163 @@ func (r *PRRepo) GetOpenPRIDsByReviewer(ctx context.Context, reviewerID domain.U
 
 	return result, nil
 }
+
+func (r *PRRepo) GetReviewerLoads(ctx context.Context, reviewerIDs []domain.UserID) (map[domain.UserID]domain.ReviewerLoad, error) {
+	const query = "SELECT u.user_id, (SELECT COUNT(*) FROM pull_request_reviewers prr JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id WHERE prr.reviewer_id = u.user_id AND pr.status = 'OPEN'), (SELECT MAX(ra.assigned_at) FROM review_assignments ra WHERE ra.reviewer_id = u.user_id) FROM unnest($1::text[]) AS u(user_id)"
+
+	ids := make([]string, 0, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		ids = append(ids, string(id))
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get reviewer loads: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[domain.UserID]domain.ReviewerLoad, len(reviewerIDs))
+
+	for rows.Next() {
+		var (
+			id             string
+			openReviews    int
+			lastAssignedAt sql.NullTime
+		)
+
+		if err := rows.Scan(&id, &openReviews, &lastAssignedAt); err != nil {
+			return nil, fmt.Errorf("scan reviewer load: %w", err)
+		}
+
+		load := domain.ReviewerLoad{
+			UserID:      domain.UserID(id),
+			OpenReviews: openReviews,
+		}
+		if lastAssignedAt.Valid {
+			t := lastAssignedAt.Time
+			load.LastAssignedAt = &t
+		}
+
+		result[load.UserID] = load
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reviewer loads: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *PRRepo) ListAssignments(ctx context.Context, prID domain.PullRequestID) ([]domain.ReviewAssignment, error) {
+	const query = "SELECT id, pull_request_id, reviewer_id, assigned_at, unassigned_at, reason, actor FROM review_assignments WHERE pull_request_id = $1 ORDER BY assigned_at, id"
+
+	rows, err := r.db.QueryContext(ctx, query, string(prID))
+	if err != nil {
+		return nil, fmt.Errorf("list assignments: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.ReviewAssignment
+
+	for rows.Next() {
+		var (
+			a                      domain.ReviewAssignment
+			id, reviewerID, reason string
+			unassignedAt           sql.NullTime
+			actor                  sql.NullString
+		)
+
+		if err := rows.Scan(&a.ID, &id, &reviewerID, &a.AssignedAt, &unassignedAt, &reason, &actor); err != nil {
+			return nil, fmt.Errorf("scan assignment: %w", err)
+		}
+
+		a.PullRequestID = domain.PullRequestID(id)
+		a.ReviewerID = domain.UserID(reviewerID)
+		a.Reason = domain.AssignmentReason(reason)
+		a.Actor = domain.UserID(actor.String)
+		if unassignedAt.Valid {
+			t := unassignedAt.Time
+			a.UnassignedAt = &t
+		}
+
+		result = append(result, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate assignments: %w", err)
+	}
+
+	return result, nil
+}
+
+// ListPendingReviews returns pending reviews of open PRs that are due for a
+// reminder or an escalation at now, longest waiting first. The SLA clock
+// restarts when a PR is reopened or leaves draft.
+func (r *PRRepo) ListPendingReviews(ctx context.Context, now time.Time, limit int) ([]domain.PendingReview, error) {
+	const query = "SELECT prr.pull_request_id, prr.reviewer_id, u.team_name, sla.since, prr.reminded_at, prr.escalated_at, sla.reminder_after_hours, sla.escalate_after_hours FROM pull_request_reviewers prr JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id JOIN users u ON u.user_id = prr.reviewer_id LEFT JOIN team_settings s ON s.team_name = u.team_name CROSS JOIN LATERAL (SELECT GREATEST(prr.assigned_at, (SELECT MAX(e.created_at) FROM pr_events e WHERE e.pull_request_id = prr.pull_request_id AND e.event_type IN ('reopened', 'ready_for_review'))) AS since, COALESCE(s.reminder_after_hours, $2) AS reminder_after_hours, COALESCE(s.escalate_after_hours, $3) AS escalate_after_hours) sla WHERE pr.status = 'OPEN' AND prr.state = 'PENDING' AND (prr.sla_retry_at IS NULL OR prr.sla_retry_at <= $1) AND ((sla.reminder_after_hours > 0 AND prr.reminded_at IS NULL AND sla.since <= $1 - make_interval(hours => sla.reminder_after_hours)) OR (sla.escalate_after_hours > 0 AND prr.escalated_at IS NULL AND sla.since <= $1 - make_interval(hours => sla.escalate_after_hours))) ORDER BY sla.since, prr.pull_request_id, prr.reviewer_id LIMIT $4"
+
+	rows, err := r.db.QueryContext(ctx, query, now, domain.DefaultReminderAfterHours, domain.DefaultEscalateAfterHours, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list pending reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.PendingReview
+
+	for rows.Next() {
+		var (
+			prID, reviewerID, teamName   string
+			p                            domain.PendingReview
+			remindedAt, escalatedAt      sql.NullTime
+			reminderHours, escalateHours int
+		)
+
+		if err := rows.Scan(&prID, &reviewerID, &teamName, &p.Since, &remindedAt, &escalatedAt, &reminderHours, &escalateHours); err != nil {
+			return nil, fmt.Errorf("scan pending review: %w", err)
+		}
+
+		p.PullRequestID = domain.PullRequestID(prID)
+		p.ReviewerID = domain.UserID(reviewerID)
+		p.TeamName = domain.TeamName(teamName)
+		p.ReminderAfter = time.Duration(reminderHours) * time.Hour
+		p.EscalateAfter = time.Duration(escalateHours) * time.Hour
+		if remindedAt.Valid {
+			t := remindedAt.Time
+			p.RemindedAt = &t
+		}
+		if escalatedAt.Valid {
+			t := escalatedAt.Time
+			p.EscalatedAt = &t
+		}
+
+		result = append(result, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate pending reviews: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *PRRepo) MarkReminded(ctx context.Context, prID domain.PullRequestID, reviewerID domain.UserID, at time.Time) error {
+	return r.markSLA(ctx, "reminded_at", prID, reviewerID, at)
+}
+
+func (r *PRRepo) MarkEscalated(ctx context.Context, prID domain.PullRequestID, reviewerID domain.UserID, at time.Time) error {
+	return r.markSLA(ctx, "escalated_at", prID, reviewerID, at)
+}
+
+// PostponeSLA hides the review from ListPendingReviews until the given time.
+func (r *PRRepo) PostponeSLA(ctx context.Context, prID domain.PullRequestID, reviewerID domain.UserID, until time.Time) error {
+	return r.markSLA(ctx, "sla_retry_at", prID, reviewerID, until)
+}
+
+func (r *PRRepo) markSLA(ctx context.Context, column string, prID domain.PullRequestID, reviewerID domain.UserID, at time.Time) error {
+	query := "UPDATE pull_request_reviewers SET " + column + " = $3 WHERE pull_request_id = $1 AND reviewer_id = $2"
+
+	if _, err := r.db.ExecContext(ctx, query, string(prID), string(reviewerID), at); err != nil {
+		return fmt.Errorf("set %s: %w", column, err)
+	}
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/freeholder/pr-reviewer-service/internal/domain"
 )
@@ -16,10 +18,34 @@ func NewStatsRepo(db *sql.DB) *StatsRepo {
 	return &StatsRepo{db: db}
 }
 
-func (r *StatsRepo) GetReviewerStats(ctx context.Context) ([]domain.ReviewerStats, error) {
-	const query = "SELECT u.user_id, u.username, COUNT(prr.pull_request_id) AS assigned_count FROM users u LEFT JOIN pull_request_reviewers prr ON prr.reviewer_id = u.user_id GROUP BY u.user_id, u.username ORDER BY assigned_count DESC, u.user_id;"
+func (r *StatsRepo) GetReviewerStats(ctx context.Context, filter domain.ReviewerStatsFilter) ([]domain.ReviewerStats, error) {
+	var (
+		args         []any
+		assignmentOn = "ra.reviewer_id = u.user_id"
+		prOn         = "pr.pull_request_id = ra.pull_request_id AND pr.status <> 'CLOSED'"
+		where        string
+	)
 
-	rows, err := r.db.QueryContext(ctx, query)
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		assignmentOn += fmt.Sprintf(" AND ra.assigned_at >= $%d", len(args))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		assignmentOn += fmt.Sprintf(" AND ra.assigned_at < $%d", len(args))
+	}
+	if filter.Status != "" {
+		args = append(args, string(filter.Status))
+		prOn += fmt.Sprintf(" AND pr.status = $%d", len(args))
+	}
+	if filter.TeamName != "" {
+		args = append(args, string(filter.TeamName))
+		where = fmt.Sprintf(" WHERE u.team_name = $%d", len(args))
+	}
+
+	query := "SELECT u.user_id, u.username, COUNT(DISTINCT pr.pull_request_id) AS assigned_count, COUNT(DISTINCT pr.pull_request_id) FILTER (WHERE pr.status = 'OPEN' AND ra.unassigned_at IS NULL) AS open_count FROM users u LEFT JOIN review_assignments ra ON " + assignmentOn + " LEFT JOIN pull_requests pr ON " + prOn + where + " GROUP BY u.user_id, u.username ORDER BY assigned_count DESC, u.user_id;"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("get reviewer stats: %w", err)
 	}
@@ -29,7 +55,7 @@ func (r *StatsRepo) GetReviewerStats(ctx context.Context) ([]domain.ReviewerStat
 
 	for rows.Next() {
 		var st domain.ReviewerStats
-		if err := rows.Scan(&st.UserID, &st.Username, &st.AssignedCount); err != nil {
+		if err := rows.Scan(&st.UserID, &st.Username, &st.AssignedCount, &st.OpenCount); err != nil {
 			return nil, fmt.Errorf("scan reviewer stat: %w", err)
 		}
 		result = append(result, st)
@@ -41,3 +67,216 @@ func (r *StatsRepo) GetReviewerStats(ctx context.Context) ([]domain.ReviewerStat
 
 	return result, nil
 }
+
+// Time to first review is measured from PR creation for teams and from the
+// reviewer's own assignment for reviewers. Both metrics are bucketed into the
+// window by the moment the interval ends (first verdict or merge).
+const (
+	teamLatencySource = "SELECT u.team_name, 'merge' AS metric, EXTRACT(EPOCH FROM pr.merged_at - pr.created_at)::double precision AS secs, pr.merged_at AS at FROM pull_requests pr JOIN users u ON u.user_id = pr.author_id WHERE pr.merged_at IS NOT NULL" +
+		" UNION ALL " +
+		"SELECT u.team_name, 'first_review', EXTRACT(EPOCH FROM fr.at - pr.created_at)::double precision, fr.at FROM (SELECT pull_request_id, MIN(created_at) AS at FROM pr_events WHERE event_type = 'review_submitted' GROUP BY pull_request_id) fr JOIN pull_requests pr ON pr.pull_request_id = fr.pull_request_id JOIN users u ON u.user_id = pr.author_id"
+
+	reviewerLatencySource = "SELECT ra.reviewer_id, u.team_name, 'merge' AS metric, EXTRACT(EPOCH FROM pr.merged_at - pr.created_at)::double precision AS secs, pr.merged_at AS at FROM (SELECT DISTINCT pull_request_id, reviewer_id FROM review_assignments) ra JOIN pull_requests pr ON pr.pull_request_id = ra.pull_request_id JOIN users u ON u.user_id = pr.author_id WHERE pr.merged_at IS NOT NULL" +
+		" UNION ALL " +
+		"SELECT fr.reviewer_id, u.team_name, 'first_review', EXTRACT(EPOCH FROM fr.at - a.assigned_at)::double precision, fr.at FROM (SELECT pull_request_id, reviewer_id, MIN(created_at) AS at FROM pr_events WHERE event_type = 'review_submitted' GROUP BY pull_request_id, reviewer_id) fr JOIN (SELECT pull_request_id, reviewer_id, MIN(assigned_at) AS assigned_at FROM review_assignments GROUP BY pull_request_id, reviewer_id) a ON a.pull_request_id = fr.pull_request_id AND a.reviewer_id = fr.reviewer_id JOIN pull_requests pr ON pr.pull_request_id = fr.pull_request_id JOIN users u ON u.user_id = pr.author_id"
+)
+
+func latencyWhere(filter domain.LatencyFilter) (string, []any) {
+	var (
+		conds []string
+		args  []any
+	)
+
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conds = append(conds, fmt.Sprintf("s.at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conds = append(conds, fmt.Sprintf("s.at < $%d", len(args)))
+	}
+	if filter.TeamName != "" {
+		args = append(args, string(filter.TeamName))
+		conds = append(conds, fmt.Sprintf("s.team_name = $%d", len(args)))
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+func (r *StatsRepo) GetTeamLatency(ctx context.Context, filter domain.LatencyFilter) ([]domain.TeamLatency, error) {
+	where, args := latencyWhere(filter)
+	query := "SELECT s.team_name, s.metric, COUNT(*), percentile_cont(0.5) WITHIN GROUP (ORDER BY s.secs), percentile_cont(0.9) WITHIN GROUP (ORDER BY s.secs) FROM (" + teamLatencySource + ") s" + where + " GROUP BY s.team_name, s.metric ORDER BY s.team_name"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get team latency: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.TeamLatency
+	index := make(map[domain.TeamName]int)
+
+	for rows.Next() {
+		var (
+			teamName, metric string
+			p                domain.LatencyPercentiles
+		)
+		if err := rows.Scan(&teamName, &metric, &p.Count, &p.P50Seconds, &p.P90Seconds); err != nil {
+			return nil, fmt.Errorf("scan team latency: %w", err)
+		}
+
+		i, ok := index[domain.TeamName(teamName)]
+		if !ok {
+			i = len(result)
+			index[domain.TeamName(teamName)] = i
+			result = append(result, domain.TeamLatency{TeamName: domain.TeamName(teamName)})
+		}
+
+		switch domain.LatencyMetric(metric) {
+		case domain.LatencyTimeToMerge:
+			result[i].TimeToMerge = p
+		case domain.LatencyTimeToFirstReview:
+			result[i].TimeToFirstReview = p
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate team latency: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *StatsRepo) GetReviewerLatency(ctx context.Context, filter domain.LatencyFilter) ([]domain.ReviewerLatency, error) {
+	where, args := latencyWhere(filter)
+	query := "SELECT s.reviewer_id, u.username, s.metric, COUNT(*), percentile_cont(0.5) WITHIN GROUP (ORDER BY s.secs), percentile_cont(0.9) WITHIN GROUP (ORDER BY s.secs) FROM (" + reviewerLatencySource + ") s JOIN users u ON u.user_id = s.reviewer_id" + where + " GROUP BY s.reviewer_id, u.username, s.metric ORDER BY s.reviewer_id"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get reviewer latency: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.ReviewerLatency
+	index := make(map[domain.UserID]int)
+
+	for rows.Next() {
+		var (
+			userID, username, metric string
+			p                        domain.LatencyPercentiles
+		)
+		if err := rows.Scan(&userID, &username, &metric, &p.Count, &p.P50Seconds, &p.P90Seconds); err != nil {
+			return nil, fmt.Errorf("scan reviewer latency: %w", err)
+		}
+
+		i, ok := index[domain.UserID(userID)]
+		if !ok {
+			i = len(result)
+			index[domain.UserID(userID)] = i
+			result = append(result, domain.ReviewerLatency{UserID: domain.UserID(userID), Username: username})
+		}
+
+		switch domain.LatencyMetric(metric) {
+		case domain.LatencyTimeToMerge:
+			result[i].TimeToMerge = p
+		case domain.LatencyTimeToFirstReview:
+			result[i].TimeToFirstReview = p
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reviewer latency: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *StatsRepo) GetMemberActivity(ctx context.Context, teamName domain.TeamName, to time.Time) ([]domain.MemberActivity, error) {
+	query := "SELECT u.user_id, u.username, u.team_name, u.is_active, c.is_active, c.changed_at FROM users u LEFT JOIN user_activity_changes c ON c.user_id = u.user_id AND c.changed_at < $1 WHERE u.team_name IS NOT NULL"
+	args := []any{to}
+
+	if teamName != "" {
+		args = append(args, string(teamName))
+		query += fmt.Sprintf(" AND u.team_name = $%d", len(args))
+	}
+	query += " ORDER BY u.team_name, u.user_id, c.changed_at, c.id"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get member activity: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.MemberActivity
+
+	for rows.Next() {
+		var (
+			userID, username, tn string
+			isActive             bool
+			changeActive         sql.NullBool
+			changedAt            sql.NullTime
+		)
+		if err := rows.Scan(&userID, &username, &tn, &isActive, &changeActive, &changedAt); err != nil {
+			return nil, fmt.Errorf("scan member activity: %w", err)
+		}
+
+		if n := len(result); n == 0 || result[n-1].UserID != domain.UserID(userID) {
+			result = append(result, domain.MemberActivity{
+				UserID:   domain.UserID(userID),
+				Username: username,
+				TeamName: domain.TeamName(tn),
+				IsActive: isActive,
+			})
+		}
+
+		if changeActive.Valid {
+			m := &result[len(result)-1]
+			m.Changes = append(m.Changes, domain.ActivityChange{IsActive: changeActive.Bool, ChangedAt: changedAt.Time})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate member activity: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *StatsRepo) GetAssignmentCounts(ctx context.Context, from, to time.Time, teamName domain.TeamName) (map[domain.UserID]int64, error) {
+	query := "SELECT ra.reviewer_id, COUNT(DISTINCT ra.pull_request_id) FROM review_assignments ra JOIN users u ON u.user_id = ra.reviewer_id WHERE ra.assigned_at >= $1 AND ra.assigned_at < $2"
+	args := []any{from, to}
+
+	if teamName != "" {
+		args = append(args, string(teamName))
+		query += fmt.Sprintf(" AND u.team_name = $%d", len(args))
+	}
+	query += " GROUP BY ra.reviewer_id"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get assignment counts: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[domain.UserID]int64)
+
+	for rows.Next() {
+		var (
+			userID string
+			count  int64
+		)
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("scan assignment count: %w", err)
+		}
+		result[domain.UserID(userID)] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate assignment counts: %w", err)
+	}
+
+	return result, nil
+}
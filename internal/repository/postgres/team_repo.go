@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/freeholder/pr-reviewer-service/internal/domain"
 )
@@ -22,9 +23,14 @@ func (r *TeamRepo) CreateTeam(ctx context.Context, team domain.Team) error {
 		return err
 	}
 
+	strategy := team.ReviewerStrategy
+	if strategy == "" {
+		strategy = domain.ReviewerStrategyLeastLoaded
+	}
+
 	_, err := r.db.ExecContext(ctx,
-		"INSERT INTO teams (team_name) VALUES ($1)",
-		string(team.Name))
+		"INSERT INTO teams (team_name, reviewer_strategy) VALUES ($1, $2)",
+		string(team.Name), string(strategy))
 
 	if err != nil {
 		if isUnique(err) {
@@ -36,8 +42,8 @@ func (r *TeamRepo) CreateTeam(ctx context.Context, team domain.Team) error {
 }
 
 func (r *TeamRepo) GetTeamByName(ctx context.Context, name domain.TeamName) (domain.Team, error) {
-	var teamName string
-	err := r.db.QueryRowContext(ctx, "SELECT team_name FROM teams WHERE team_name = $1", string(name)).Scan(&teamName)
+	var teamName, strategy string
+	err := r.db.QueryRowContext(ctx, "SELECT team_name, reviewer_strategy FROM teams WHERE team_name = $1", string(name)).Scan(&teamName, &strategy)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.Team{}, domain.NewDomainError(domain.ErrNotFound, "team not found")
@@ -46,7 +52,7 @@ func (r *TeamRepo) GetTeamByName(ctx context.Context, name domain.TeamName) (dom
 	}
 
 	rows, err := r.db.QueryContext(ctx,
-		"SELECT user_id, username, is_active FROM users WHERE team_name = $1 ORDER BY user_id", string(name))
+		"SELECT user_id, username, is_active, COALESCE(slack_handle, ''), COALESCE(email, ''), max_open_reviews, array_to_string(skills, ',') FROM users WHERE team_name = $1 ORDER BY user_id", string(name))
 	if err != nil {
 		return domain.Team{}, fmt.Errorf("get team members: %w", err)
 	}
@@ -56,10 +62,11 @@ func (r *TeamRepo) GetTeamByName(ctx context.Context, name domain.TeamName) (dom
 	var members []domain.User
 	for rows.Next() {
 		var u domain.User
-		var userID, username string
+		var userID, username, skills string
 		var isActive bool
+		var maxOpen sql.NullInt64
 
-		if err := rows.Scan(&userID, &username, &isActive); err != nil {
+		if err := rows.Scan(&userID, &username, &isActive, &u.SlackHandle, &u.Email, &maxOpen, &skills); err != nil {
 			return domain.Team{}, fmt.Errorf("scan team member: %w", err)
 		}
 
@@ -67,6 +74,8 @@ func (r *TeamRepo) GetTeamByName(ctx context.Context, name domain.TeamName) (dom
 		u.Username = username
 		u.TeamName = name
 		u.IsActive = isActive
+		u.MaxOpenReviews = intPtr(maxOpen)
+		u.Skills = splitTags(skills)
 
 		members = append(members, u)
 	}
@@ -75,7 +84,185 @@ func (r *TeamRepo) GetTeamByName(ctx context.Context, name domain.TeamName) (dom
 	}
 
 	return domain.Team{
-		Name:    name,
-		Members: members,
+		Name:             name,
+		Members:          members,
+		ReviewerStrategy: domain.ReviewerStrategy(strategy),
 	}, nil
 }
+
+func (r *TeamRepo) GetReviewerStrategy(ctx context.Context, name domain.TeamName) (domain.ReviewerStrategy, error) {
+	var strategy string
+	err := r.db.QueryRowContext(ctx, "SELECT reviewer_strategy FROM teams WHERE team_name = $1", string(name)).Scan(&strategy)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.NewDomainError(domain.ErrNotFound, "team not found")
+		}
+		return "", fmt.Errorf("get reviewer strategy: %w", err)
+	}
+	return domain.ReviewerStrategy(strategy), nil
+}
+
+func (r *TeamRepo) SetReviewerStrategy(ctx context.Context, name domain.TeamName, strategy domain.ReviewerStrategy) error {
+	if err := strategy.Validate(); err != nil {
+		return err
+	}
+
+	res, err := r.db.ExecContext(ctx, "UPDATE teams SET reviewer_strategy = $1 WHERE team_name = $2", string(strategy), string(name))
+	if err != nil {
+		return fmt.Errorf("set reviewer strategy: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if affected == 0 {
+		return domain.NewDomainError(domain.ErrNotFound, "team not found")
+	}
+	return nil
+}
+
+func (r *TeamRepo) GetSettings(ctx context.Context, name domain.TeamName) (domain.TeamSettings, error) {
+	const query = "SELECT t.team_name, COALESCE(s.min_reviewers, $2), COALESCE(s.max_reviewers, $3), COALESCE(s.required_approvals, $4), COALESCE(s.block_on_changes_requested, $5), COALESCE(s.require_all_approved, $6), COALESCE(s.slack_webhook_url, ''), COALESCE(s.reminder_after_hours, $7), COALESCE(s.escalate_after_hours, $8), COALESCE(s.max_open_reviews, $9) FROM teams t LEFT JOIN team_settings s ON s.team_name = t.team_name WHERE t.team_name = $1"
+
+	var (
+		teamName string
+		settings domain.TeamSettings
+	)
+
+	d := domain.DefaultTeamSettings(name)
+	err := r.db.QueryRowContext(ctx, query, string(name), d.MinReviewers, d.MaxReviewers, d.RequiredApprovals, d.BlockOnChangesRequested, d.RequireAllApproved, d.ReminderAfterHours, d.EscalateAfterHours, d.MaxOpenReviews).
+		Scan(&teamName, &settings.MinReviewers, &settings.MaxReviewers, &settings.RequiredApprovals, &settings.BlockOnChangesRequested, &settings.RequireAllApproved, &settings.SlackWebhookURL, &settings.ReminderAfterHours, &settings.EscalateAfterHours, &settings.MaxOpenReviews)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.TeamSettings{}, domain.NewDomainError(domain.ErrNotFound, "team not found")
+		}
+		return domain.TeamSettings{}, fmt.Errorf("get team settings: %w", err)
+	}
+
+	settings.TeamName = domain.TeamName(teamName)
+	return settings, nil
+}
+
+func (r *TeamRepo) UpsertSettings(ctx context.Context, settings domain.TeamSettings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	const query = "INSERT INTO team_settings (team_name, min_reviewers, max_reviewers, required_approvals, block_on_changes_requested, require_all_approved, slack_webhook_url, reminder_after_hours, escalate_after_hours, max_open_reviews) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT (team_name) DO UPDATE SET min_reviewers = EXCLUDED.min_reviewers, max_reviewers = EXCLUDED.max_reviewers, required_approvals = EXCLUDED.required_approvals, block_on_changes_requested = EXCLUDED.block_on_changes_requested, require_all_approved = EXCLUDED.require_all_approved, slack_webhook_url = EXCLUDED.slack_webhook_url, reminder_after_hours = EXCLUDED.reminder_after_hours, escalate_after_hours = EXCLUDED.escalate_after_hours, max_open_reviews = EXCLUDED.max_open_reviews"
+
+	_, err := r.db.ExecContext(ctx, query, string(settings.TeamName), settings.MinReviewers, settings.MaxReviewers, settings.RequiredApprovals, settings.BlockOnChangesRequested, settings.RequireAllApproved, nullString(settings.SlackWebhookURL), settings.ReminderAfterHours, settings.EscalateAfterHours, settings.MaxOpenReviews)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domain.NewDomainError(domain.ErrNotFound, "team not found")
+		}
+		return fmt.Errorf("upsert team settings: %w", err)
+	}
+	return nil
+}
+
+// GetFallbackTeams returns the teams that lend reviewers to name, in order.
+func (r *TeamRepo) GetFallbackTeams(ctx context.Context, name domain.TeamName) ([]domain.TeamName, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT fallback_team FROM team_fallbacks WHERE team_name = $1 ORDER BY position", string(name))
+	if err != nil {
+		return nil, fmt.Errorf("get fallback teams: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.TeamName
+
+	for rows.Next() {
+		var team string
+		if err := rows.Scan(&team); err != nil {
+			return nil, fmt.Errorf("scan fallback team: %w", err)
+		}
+		result = append(result, domain.TeamName(team))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate fallback teams: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *TeamRepo) SetFallbackTeams(ctx context.Context, name domain.TeamName, fallbacks []domain.TeamName) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM team_fallbacks WHERE team_name = $1", string(name)); err != nil {
+		return fmt.Errorf("delete fallback teams: %w", err)
+	}
+
+	for i, f := range fallbacks {
+		_, err = tx.ExecContext(ctx, "INSERT INTO team_fallbacks (team_name, fallback_team, position) VALUES ($1, $2, $3)", string(name), string(f), i)
+		if err != nil {
+			if isForeignKeyViolation(err) {
+				return domain.NewDomainError(domain.ErrNotFound, "team not found")
+			}
+			return fmt.Errorf("insert fallback team %s: %w", f, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// GetCodeowners returns the team's CODEOWNERS ruleset; a team that never
+// uploaded one gets an empty ruleset in PREFER mode.
+func (r *TeamRepo) GetCodeowners(ctx context.Context, name domain.TeamName) (domain.Codeowners, error) {
+	const query = "SELECT t.team_name, COALESCE(c.content, ''), COALESCE(c.mode, $2), c.updated_at FROM teams t LEFT JOIN team_codeowners c ON c.team_name = t.team_name WHERE t.team_name = $1"
+
+	var (
+		teamName  string
+		mode      string
+		updatedAt sql.NullTime
+		co        domain.Codeowners
+	)
+
+	err := r.db.QueryRowContext(ctx, query, string(name), string(domain.CodeownersModePrefer)).Scan(&teamName, &co.Content, &mode, &updatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Codeowners{}, domain.NewDomainError(domain.ErrNotFound, "team not found")
+		}
+		return domain.Codeowners{}, fmt.Errorf("get team codeowners: %w", err)
+	}
+
+	co.TeamName = domain.TeamName(teamName)
+	co.Mode = domain.CodeownersMode(mode)
+	if updatedAt.Valid {
+		co.UpdatedAt = &updatedAt.Time
+	}
+	return co, nil
+}
+
+func (r *TeamRepo) SetCodeowners(ctx context.Context, co domain.Codeowners) (domain.Codeowners, error) {
+	if err := co.Validate(); err != nil {
+		return domain.Codeowners{}, err
+	}
+
+	const query = "INSERT INTO team_codeowners (team_name, content, mode) VALUES ($1, $2, $3) ON CONFLICT (team_name) DO UPDATE SET content = EXCLUDED.content, mode = EXCLUDED.mode, updated_at = now() RETURNING updated_at"
+
+	var updatedAt time.Time
+
+	err := r.db.QueryRowContext(ctx, query, string(co.TeamName), co.Content, string(co.Mode)).Scan(&updatedAt)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domain.Codeowners{}, domain.NewDomainError(domain.ErrNotFound, "team not found")
+		}
+		return domain.Codeowners{}, fmt.Errorf("upsert team codeowners: %w", err)
+	}
+
+	co.UpdatedAt = &updatedAt
+	return co, nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/freeholder/pr-reviewer-service/internal/domain"
 )
@@ -34,27 +35,55 @@ func (r *UserRepo) UpdateUsers(ctx context.Context, users []domain.User) error {
 		}
 	}()
 
-	const query = "INSERT INTO users (user_id, username, team_name, is_active) VALUES ($1, $2, $3, $4) ON CONFLICT (user_id) DO UPDATE SET username = EXCLUDED.username, team_name = EXCLUDED.team_name, is_active = EXCLUDED.is_active;"
+	const query = "INSERT INTO users (user_id, username, team_name, is_active, slack_handle, email, max_open_reviews, skills) VALUES ($1, $2, $3, $4, $5, $6, $7, $8::text[]) ON CONFLICT (user_id) DO UPDATE SET username = EXCLUDED.username, team_name = EXCLUDED.team_name, is_active = EXCLUDED.is_active, slack_handle = COALESCE(EXCLUDED.slack_handle, users.slack_handle), email = COALESCE(EXCLUDED.email, users.email), max_open_reviews = COALESCE(EXCLUDED.max_open_reviews, users.max_open_reviews), skills = CASE WHEN cardinality(EXCLUDED.skills) = 0 THEN users.skills ELSE EXCLUDED.skills END;"
 
 	for _, u := range users {
 		if err := u.Validate(); err != nil {
 			return err
 		}
 
-		_, err = tx.ExecContext(ctx, query, string(u.ID), u.Username, string(u.TeamName), u.IsActive)
+		_, err = tx.ExecContext(ctx, query, string(u.ID), u.Username, string(u.TeamName), u.IsActive, nullString(u.SlackHandle), nullString(u.Email), nullInt(u.MaxOpenReviews), tagList(u.Skills))
 
 		if err != nil {
 			return fmt.Errorf("update user %s: %w", u.ID, err)
 		}
+
+		if err = logActivityChange(ctx, tx, u.ID, u.IsActive); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// logActivityChange records the user's is_active flag unless it matches the
+// last recorded value, and publishes the change through the outbox.
+func logActivityChange(ctx context.Context, tx *sql.Tx, id domain.UserID, isActive bool) error {
+	const query = "INSERT INTO user_activity_changes (user_id, is_active) SELECT $1::text, $2::boolean WHERE (SELECT c.is_active FROM user_activity_changes c WHERE c.user_id = $1::text ORDER BY c.changed_at DESC, c.id DESC LIMIT 1) IS DISTINCT FROM $2::boolean RETURNING changed_at"
+
+	var changedAt time.Time
+
+	err := tx.QueryRowContext(ctx, query, string(id), isActive).Scan(&changedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("log activity change for %s: %w", id, err)
+	}
+
+	return insertOutbox(ctx, tx, eventPayload{
+		Event:      domain.EventUserActivityChanged,
+		UserID:     string(id),
+		IsActive:   &isActive,
+		OccurredAt: changedAt.UTC(),
+	})
+}
+
 func (r *UserRepo) GetUserByID(ctx context.Context, id domain.UserID) (domain.User, error) {
 	var u domain.User
-	var teamName string
+	var teamName, skills string
+	var maxOpen sql.NullInt64
 
-	err := r.db.QueryRowContext(ctx, "SELECT user_id, username, team_name, is_active FROM users WHERE user_id = $1", string(id)).Scan(&id, &u.Username, &teamName, &u.IsActive)
+	err := r.db.QueryRowContext(ctx, "SELECT user_id, username, team_name, is_active, COALESCE(slack_handle, ''), COALESCE(email, ''), max_open_reviews, array_to_string(skills, ',') FROM users WHERE user_id = $1", string(id)).Scan(&id, &u.Username, &teamName, &u.IsActive, &u.SlackHandle, &u.Email, &maxOpen, &skills)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -64,14 +93,27 @@ func (r *UserRepo) GetUserByID(ctx context.Context, id domain.UserID) (domain.Us
 	}
 	u.ID = id
 	u.TeamName = domain.TeamName(teamName)
+	u.MaxOpenReviews = intPtr(maxOpen)
+	u.Skills = splitTags(skills)
 	return u, nil
 }
 
-func (r *UserRepo) SetUserActive(ctx context.Context, id domain.UserID, isActive bool) (domain.User, error) {
+func (r *UserRepo) SetUserActive(ctx context.Context, id domain.UserID, isActive bool) (_ domain.User, err error) {
 	var u domain.User
-	var userID, username, teamName string
+	var userID, username, teamName, skills string
+	var maxOpen sql.NullInt64
 
-	err := r.db.QueryRowContext(ctx, "UPDATE users SET is_active = $1 WHERE user_id = $2 RETURNING user_id, username, team_name, is_active", isActive, string(id)).Scan(&userID, &username, &teamName, &u.IsActive)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	err = tx.QueryRowContext(ctx, "UPDATE users SET is_active = $1 WHERE user_id = $2 RETURNING user_id, username, team_name, is_active, COALESCE(slack_handle, ''), COALESCE(email, ''), max_open_reviews, array_to_string(skills, ',')", isActive, string(id)).Scan(&userID, &username, &teamName, &u.IsActive, &u.SlackHandle, &u.Email, &maxOpen, &skills)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -80,15 +122,71 @@ func (r *UserRepo) SetUserActive(ctx context.Context, id domain.UserID, isActive
 		return domain.User{}, fmt.Errorf("set user active: %w", err)
 	}
 
+	if err = logActivityChange(ctx, tx, id, isActive); err != nil {
+		return domain.User{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return domain.User{}, fmt.Errorf("commit tx: %w", err)
+	}
+
+	u.ID = domain.UserID(userID)
+	u.Username = username
+	u.TeamName = domain.TeamName(teamName)
+	u.MaxOpenReviews = intPtr(maxOpen)
+	u.Skills = splitTags(skills)
+
+	return u, nil
+}
+
+func (r *UserRepo) SetSlackHandle(ctx context.Context, id domain.UserID, handle string) (domain.User, error) {
+	return r.setColumn(ctx, "slack_handle", id, nullString(handle))
+}
+
+func (r *UserRepo) SetEmail(ctx context.Context, id domain.UserID, email string) (domain.User, error) {
+	return r.setColumn(ctx, "email", id, nullString(email))
+}
+
+func (r *UserRepo) SetSkills(ctx context.Context, id domain.UserID, skills []string) (domain.User, error) {
+	return r.setColumn(ctx, "skills", id, tagList(skills))
+}
+
+func (r *UserRepo) SetMaxOpenReviews(ctx context.Context, id domain.UserID, limit *int) (domain.User, error) {
+	return r.setColumn(ctx, "max_open_reviews", id, nullInt(limit))
+}
+
+// setColumn updates a user column; column is never user input.
+func (r *UserRepo) setColumn(ctx context.Context, column string, id domain.UserID, value any) (domain.User, error) {
+	var (
+		u                                  domain.User
+		userID, username, teamName, skills string
+		maxOpen                            sql.NullInt64
+	)
+
+	query := "UPDATE users SET " + column + " = $1 WHERE user_id = $2 RETURNING user_id, username, team_name, is_active, COALESCE(slack_handle, ''), COALESCE(email, ''), max_open_reviews, array_to_string(skills, ',')"
+
+	err := r.db.QueryRowContext(ctx, query, value, string(id)).
+		Scan(&userID, &username, &teamName, &u.IsActive, &u.SlackHandle, &u.Email, &maxOpen, &skills)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.User{}, domain.NewDomainError(domain.ErrNotFound, "user not found")
+		}
+		return domain.User{}, fmt.Errorf("set %s: %w", column, err)
+	}
+
 	u.ID = domain.UserID(userID)
 	u.Username = username
 	u.TeamName = domain.TeamName(teamName)
+	u.MaxOpenReviews = intPtr(maxOpen)
+	u.Skills = splitTags(skills)
 
 	return u, nil
 }
 
+// GetActiveTeamMembersExcept returns active members of the team that are
+// not on an absence right now.
 func (r *UserRepo) GetActiveTeamMembersExcept(ctx context.Context, teamName domain.TeamName, exclude []domain.UserID) ([]domain.User, error) {
-	query := "SELECT user_id, username, team_name, is_active FROM users WHERE team_name = $1 AND is_active = TRUE"
+	query := "SELECT user_id, username, team_name, is_active, COALESCE(email, ''), max_open_reviews, array_to_string(skills, ',') FROM users u WHERE team_name = $1 AND is_active = TRUE AND NOT EXISTS (SELECT 1 FROM user_absences a WHERE a.user_id = u.user_id AND a.starts_at <= now() AND a.ends_at > now())"
 
 	args := []any{string(teamName)}
 
@@ -108,15 +206,18 @@ func (r *UserRepo) GetActiveTeamMembersExcept(ctx context.Context, teamName doma
 
 	for rows.Next() {
 		var u domain.User
-		var userID, username, tn string
+		var userID, username, tn, skills string
+		var maxOpen sql.NullInt64
 
-		if err := rows.Scan(&userID, &username, &tn, &u.IsActive); err != nil {
+		if err := rows.Scan(&userID, &username, &tn, &u.IsActive, &u.Email, &maxOpen, &skills); err != nil {
 			return nil, fmt.Errorf("scan active member: %w", err)
 		}
 
 		u.ID = domain.UserID(userID)
 		u.Username = username
 		u.TeamName = domain.TeamName(tn)
+		u.MaxOpenReviews = intPtr(maxOpen)
+		u.Skills = splitTags(skills)
 
 		result = append(result, u)
 	}
@@ -0,0 +1,304 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+const (
+	subscriptionColumns = "id, url, secret, array_to_string(event_types, ','), is_active, created_at"
+	deliveryColumns     = "id, subscription_id, event_type, payload, status, attempts, next_attempt_at, last_attempt_at, response_status, last_error, created_at, delivered_at"
+)
+
+type WebhookRepo struct {
+	db *sql.DB
+}
+
+func NewWebhookRepo(db *sql.DB) *WebhookRepo {
+	return &WebhookRepo{db: db}
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSubscription(row rowScanner) (domain.WebhookSubscription, error) {
+	var (
+		s          domain.WebhookSubscription
+		eventTypes string
+	)
+
+	if err := row.Scan(&s.ID, &s.URL, &s.Secret, &eventTypes, &s.IsActive, &s.CreatedAt); err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+
+	if eventTypes != "" {
+		for _, t := range strings.Split(eventTypes, ",") {
+			s.EventTypes = append(s.EventTypes, domain.EventType(t))
+		}
+	}
+	return s, nil
+}
+
+func eventTypeStrings(types []domain.EventType) []string {
+	result := make([]string, 0, len(types))
+	for _, t := range types {
+		result = append(result, string(t))
+	}
+	return result
+}
+
+func (r *WebhookRepo) CreateSubscription(ctx context.Context, s domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	query := "INSERT INTO webhook_subscriptions (url, secret, event_types, is_active) VALUES ($1, $2, $3::text[], $4) RETURNING " + subscriptionColumns
+
+	created, err := scanSubscription(r.db.QueryRowContext(ctx, query, s.URL, s.Secret, eventTypeStrings(s.EventTypes), s.IsActive))
+	if err != nil {
+		return domain.WebhookSubscription{}, fmt.Errorf("create subscription: %w", err)
+	}
+	return created, nil
+}
+
+func (r *WebhookRepo) GetSubscription(ctx context.Context, id int64) (domain.WebhookSubscription, error) {
+	s, err := scanSubscription(r.db.QueryRowContext(ctx, "SELECT "+subscriptionColumns+" FROM webhook_subscriptions WHERE id = $1", id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.WebhookSubscription{}, domain.NewDomainError(domain.ErrNotFound, "subscription not found")
+		}
+		return domain.WebhookSubscription{}, fmt.Errorf("get subscription: %w", err)
+	}
+	return s, nil
+}
+
+func (r *WebhookRepo) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+subscriptionColumns+" FROM webhook_subscriptions ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("list subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.WebhookSubscription
+
+	for rows.Next() {
+		s, err := scanSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan subscription: %w", err)
+		}
+		result = append(result, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate subscriptions: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *WebhookRepo) UpdateSubscription(ctx context.Context, s domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	query := "UPDATE webhook_subscriptions SET url = $2, secret = $3, event_types = $4::text[], is_active = $5 WHERE id = $1 RETURNING " + subscriptionColumns
+
+	updated, err := scanSubscription(r.db.QueryRowContext(ctx, query, s.ID, s.URL, s.Secret, eventTypeStrings(s.EventTypes), s.IsActive))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.WebhookSubscription{}, domain.NewDomainError(domain.ErrNotFound, "subscription not found")
+		}
+		return domain.WebhookSubscription{}, fmt.Errorf("update subscription: %w", err)
+	}
+	return updated, nil
+}
+
+func (r *WebhookRepo) DeleteSubscription(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM webhook_subscriptions WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("delete subscription: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete subscription rows affected: %w", err)
+	}
+	if n == 0 {
+		return domain.NewDomainError(domain.ErrNotFound, "subscription not found")
+	}
+	return nil
+}
+
+func scanDelivery(row rowScanner) (domain.WebhookDelivery, error) {
+	var (
+		d                          domain.WebhookDelivery
+		eventType, status          string
+		lastAttemptAt, deliveredAt sql.NullTime
+		responseStatus             sql.NullInt64
+		lastError                  sql.NullString
+	)
+
+	if err := row.Scan(&d.ID, &d.SubscriptionID, &eventType, &d.Payload, &status, &d.Attempts, &d.NextAttemptAt, &lastAttemptAt, &responseStatus, &lastError, &d.CreatedAt, &deliveredAt); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	d.EventType = domain.EventType(eventType)
+	d.Status = domain.WebhookDeliveryStatus(status)
+	d.ResponseStatus = int(responseStatus.Int64)
+	d.LastError = lastError.String
+	if lastAttemptAt.Valid {
+		t := lastAttemptAt.Time
+		d.LastAttemptAt = &t
+	}
+	if deliveredAt.Valid {
+		t := deliveredAt.Time
+		d.DeliveredAt = &t
+	}
+	return d, nil
+}
+
+func (r *WebhookRepo) ListDeliveries(ctx context.Context, subscriptionID int64, status domain.WebhookDeliveryStatus, limit int) ([]domain.WebhookDelivery, error) {
+	query := "SELECT " + deliveryColumns + " FROM webhook_deliveries WHERE subscription_id = $1"
+	args := []any{subscriptionID}
+
+	if status != "" {
+		args = append(args, string(status))
+		query += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.WebhookDelivery
+
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan delivery: %w", err)
+		}
+		result = append(result, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate deliveries: %w", err)
+	}
+
+	return result, nil
+}
+
+// ClaimDueDeliveries picks pending deliveries that are due and counts the
+// attempt. The next attempt is pushed back by lease, so a delivery claimed
+// by a worker that dies is retried once the lease expires. A delivery whose
+// final attempt was lost that way is marked FAILED instead of being claimed
+// past MaxWebhookAttempts.
+func (r *WebhookRepo) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]domain.WebhookDispatch, error) {
+	const (
+		exhausted = "UPDATE webhook_deliveries SET status = 'FAILED', last_error = COALESCE(last_error, 'final attempt lost') WHERE status = 'PENDING' AND attempts >= $1 AND next_attempt_at <= now()"
+		query     = "WITH due AS (SELECT d.id FROM webhook_deliveries d WHERE d.status = 'PENDING' AND d.attempts < $3 AND d.next_attempt_at <= now() AND EXISTS (SELECT 1 FROM webhook_subscriptions s WHERE s.id = d.subscription_id AND s.is_active) ORDER BY d.next_attempt_at LIMIT $1 FOR UPDATE SKIP LOCKED) " +
+			"UPDATE webhook_deliveries d SET attempts = d.attempts + 1, last_attempt_at = now(), next_attempt_at = now() + make_interval(secs => $2) FROM due, webhook_subscriptions s WHERE d.id = due.id AND s.id = d.subscription_id " +
+			"RETURNING d.id, d.subscription_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at, d.last_attempt_at, d.response_status, d.last_error, d.created_at, d.delivered_at, s.url, s.secret"
+	)
+
+	if _, err := r.db.ExecContext(ctx, exhausted, domain.MaxWebhookAttempts); err != nil {
+		return nil, fmt.Errorf("fail exhausted deliveries: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, limit, lease.Seconds(), domain.MaxWebhookAttempts)
+	if err != nil {
+		return nil, fmt.Errorf("claim deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.WebhookDispatch
+
+	for rows.Next() {
+		var (
+			wd                         domain.WebhookDispatch
+			eventType, status          string
+			lastAttemptAt, deliveredAt sql.NullTime
+			responseStatus             sql.NullInt64
+			lastError                  sql.NullString
+		)
+		d := &wd.Delivery
+
+		if err := rows.Scan(&d.ID, &d.SubscriptionID, &eventType, &d.Payload, &status, &d.Attempts, &d.NextAttemptAt, &lastAttemptAt, &responseStatus, &lastError, &d.CreatedAt, &deliveredAt, &wd.URL, &wd.Secret); err != nil {
+			return nil, fmt.Errorf("scan claimed delivery: %w", err)
+		}
+
+		d.EventType = domain.EventType(eventType)
+		d.Status = domain.WebhookDeliveryStatus(status)
+		d.ResponseStatus = int(responseStatus.Int64)
+		d.LastError = lastError.String
+		if lastAttemptAt.Valid {
+			t := lastAttemptAt.Time
+			d.LastAttemptAt = &t
+		}
+
+		result = append(result, wd)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate claimed deliveries: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *WebhookRepo) MarkDelivered(ctx context.Context, id int64, responseStatus int) error {
+	const query = "UPDATE webhook_deliveries SET status = 'DELIVERED', response_status = $2, last_error = NULL, delivered_at = now() WHERE id = $1"
+
+	if _, err := r.db.ExecContext(ctx, query, id, responseStatus); err != nil {
+		return fmt.Errorf("mark delivery %d delivered: %w", id, err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed attempt. The delivery is retried at retryAt,
+// or given up on when retryAt is nil.
+func (r *WebhookRepo) MarkFailed(ctx context.Context, id int64, responseStatus int, errMsg string, retryAt *time.Time) error {
+	const query = "UPDATE webhook_deliveries SET status = CASE WHEN $4::timestamptz IS NULL THEN 'FAILED' ELSE 'PENDING' END, response_status = $2, last_error = $3, next_attempt_at = COALESCE($4::timestamptz, next_attempt_at) WHERE id = $1"
+
+	var status sql.NullInt64
+	if responseStatus != 0 {
+		status = sql.NullInt64{Int64: int64(responseStatus), Valid: true}
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, id, status, errMsg, retryAt); err != nil {
+		return fmt.Errorf("mark delivery %d failed: %w", id, err)
+	}
+	return nil
+}
+
+func (r *WebhookRepo) Redeliver(ctx context.Context, id int64) (domain.WebhookDelivery, error) {
+	query := "UPDATE webhook_deliveries SET status = 'PENDING', attempts = 0, next_attempt_at = now(), delivered_at = NULL WHERE id = $1 RETURNING " + deliveryColumns
+
+	d, err := scanDelivery(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.WebhookDelivery{}, domain.NewDomainError(domain.ErrNotFound, "delivery not found")
+		}
+		return domain.WebhookDelivery{}, fmt.Errorf("redeliver: %w", err)
+	}
+	return d, nil
+}
+
+// EnqueueDeliveries schedules the event for every active subscription that
+// wants it. Enqueueing the same event twice is a no-op.
+func (r *WebhookRepo) EnqueueDeliveries(ctx context.Context, e domain.OutboxEvent) (int, error) {
+	const query = "INSERT INTO webhook_deliveries (subscription_id, event_type, payload, event_id) SELECT id, $1::text, $2::jsonb, $3 FROM webhook_subscriptions WHERE is_active AND (cardinality(event_types) = 0 OR $1::text = ANY(event_types)) ON CONFLICT (subscription_id, event_id) DO NOTHING"
+
+	res, err := r.db.ExecContext(ctx, query, string(e.Type), string(e.Payload), e.ID)
+	if err != nil {
+		return 0, fmt.Errorf("enqueue %s deliveries: %w", e.Type, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("enqueue %s deliveries rows affected: %w", e.Type, err)
+	}
+	return int(n), nil
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+const (
+	absenceBatchSize  = 20
+	absenceRetryDelay = 15 * time.Minute
+)
+
+type AbsenceService struct {
+	logger   *slog.Logger
+	absences AbsenceRepository
+	users    UserRepository
+	prs      PullRequestRepository
+	prSvc    *PRService
+}
+
+func NewAbsenceService(logger *slog.Logger, absences AbsenceRepository, users UserRepository, prs PullRequestRepository, prSvc *PRService) *AbsenceService {
+	return &AbsenceService{
+		logger:   logger,
+		absences: absences,
+		users:    users,
+		prs:      prs,
+		prSvc:    prSvc,
+	}
+}
+
+func (s *AbsenceService) Create(ctx context.Context, a domain.Absence) (domain.Absence, error) {
+	if err := a.Validate(); err != nil {
+		return domain.Absence{}, err
+	}
+	if !a.EndsAt.After(time.Now()) {
+		return domain.Absence{}, domain.NewValidationError("ends_at", "must be in the future")
+	}
+
+	created, err := s.absences.CreateAbsence(ctx, a)
+	if err != nil {
+		s.logger.Error("create absence", slog.String("user_id", string(a.UserID)), slog.Any("err", err))
+		return domain.Absence{}, err
+	}
+	return created, nil
+}
+
+// List returns current and upcoming absences of the user.
+func (s *AbsenceService) List(ctx context.Context, userID domain.UserID) ([]domain.Absence, error) {
+	if userID == "" {
+		return nil, domain.NewValidationError("user_id", "must not be empty")
+	}
+
+	if _, err := s.users.GetUserByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	absences, err := s.absences.ListAbsences(ctx, userID, time.Now())
+	if err != nil {
+		s.logger.Error("list absences", slog.String("user_id", string(userID)), slog.Any("err", err))
+		return nil, err
+	}
+	return absences, nil
+}
+
+func (s *AbsenceService) Delete(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return domain.NewValidationError("id", "must be a positive integer")
+	}
+
+	if err := s.absences.DeleteAbsence(ctx, id); err != nil {
+		s.logger.Error("delete absence", slog.Int64("absence_id", id), slog.Any("err", err))
+		return err
+	}
+	return nil
+}
+
+func (s *AbsenceService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.ReassignStarted(ctx, time.Now()); err != nil {
+				s.logger.Error("reassign reviews of absent users", slog.Any("err", err))
+			}
+		}
+	}
+}
+
+// ReassignStarted hands over the open reviews of users whose absence with
+// reassign_reviews has started by now. Reviews nobody can take over stay
+// with the absent user for now; the absence is only marked reassigned once
+// all of them are handed over, so they are retried on later runs until it
+// ends. Such an absence, or one that failed, is set aside for
+// absenceRetryDelay, so it does not keep newer absences out of the batch.
+// It returns how many absences were fully processed.
+func (s *AbsenceService) ReassignStarted(ctx context.Context, now time.Time) (int, error) {
+	started, err := s.absences.ListStartedForReassign(ctx, now, absenceBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	done := 0
+	for _, a := range started {
+		left, err := s.reassignReviews(ctx, a)
+		if err != nil {
+			s.logger.Error("reassign reviews of absent user", slog.Int64("absence_id", a.ID), slog.String("user_id", string(a.UserID)), slog.Any("err", err))
+			s.postpone(ctx, a, now)
+			continue
+		}
+		if left > 0 {
+			s.logger.Warn("absent user keeps reviews, will retry", slog.Int64("absence_id", a.ID), slog.String("user_id", string(a.UserID)), slog.Int("reviews", left))
+			s.postpone(ctx, a, now)
+			continue
+		}
+		if err := s.absences.MarkReassigned(ctx, a.ID, now); err != nil {
+			s.logger.Error("mark absence reassigned", slog.Int64("absence_id", a.ID), slog.Any("err", err))
+			continue
+		}
+		done++
+	}
+
+	return done, nil
+}
+
+func (s *AbsenceService) postpone(ctx context.Context, a domain.Absence, now time.Time) {
+	if err := s.absences.PostponeReassign(ctx, a.ID, now.Add(absenceRetryDelay)); err != nil {
+		s.logger.Error("postpone absence reassign", slog.Int64("absence_id", a.ID), slog.Any("err", err))
+	}
+}
+
+// reassignReviews returns how many open reviews are still left with the
+// absent user because no replacement could be found.
+func (s *AbsenceService) reassignReviews(ctx context.Context, a domain.Absence) (int, error) {
+	prIDs, err := s.prs.GetOpenPRIDsByReviewer(ctx, a.UserID)
+	if err != nil {
+		return 0, err
+	}
+
+	left := 0
+
+	for _, prID := range prIDs {
+		log := s.logger.With(slog.Int64("absence_id", a.ID), slog.String("pr_id", string(prID)), slog.String("user_id", string(a.UserID)))
+
+		res, err := s.prSvc.reassign(ctx, ReassignInput{PullRequestID: prID, OldReviewerID: a.UserID}, domain.AssignmentReasonAbsence)
+		if err != nil {
+			var de *domain.DomainError
+			if !errors.As(err, &de) {
+				return 0, err
+			}
+			// The review may have been merged, closed or handed over
+			// meanwhile; only a missing replacement keeps it pending.
+			if de.Code == domain.ErrNoCandidate {
+				left++
+			}
+			log.Warn("review of absent user not reassigned", slog.String("code", string(de.Code)), slog.String("reason", de.Message))
+			continue
+		}
+
+		log.Info("review of absent user reassigned", slog.String("new_user_id", string(res.NewReviewerID)), slog.String("fallback_team", string(res.FallbackTeam)))
+	}
+
+	return left, nil
+}
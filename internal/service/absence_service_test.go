@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+// memTeams serves team settings, strategies, fallbacks and CODEOWNERS from
+// memory.
+type memTeams struct {
+	TeamRepository
+
+	settings   map[domain.TeamName]domain.TeamSettings
+	strategies map[domain.TeamName]domain.ReviewerStrategy
+	fallbacks  map[domain.TeamName][]domain.TeamName
+	codeowners map[domain.TeamName]domain.Codeowners
+}
+
+func (m *memTeams) GetCodeowners(_ context.Context, name domain.TeamName) (domain.Codeowners, error) {
+	return m.codeowners[name], nil
+}
+
+func (m *memTeams) GetSettings(_ context.Context, name domain.TeamName) (domain.TeamSettings, error) {
+	if s, ok := m.settings[name]; ok {
+		return s, nil
+	}
+	return domain.DefaultTeamSettings(name), nil
+}
+
+func (m *memTeams) GetReviewerStrategy(_ context.Context, name domain.TeamName) (domain.ReviewerStrategy, error) {
+	if s, ok := m.strategies[name]; ok {
+		return s, nil
+	}
+	return domain.ReviewerStrategyLeastLoaded, nil
+}
+
+func (m *memTeams) GetFallbackTeams(_ context.Context, name domain.TeamName) ([]domain.TeamName, error) {
+	return m.fallbacks[name], nil
+}
+
+// memUsers keeps users in the order they were given.
+type memUsers struct {
+	UserRepository
+
+	users []domain.User
+}
+
+func (m *memUsers) GetUserByID(_ context.Context, id domain.UserID) (domain.User, error) {
+	for _, u := range m.users {
+		if u.ID == id {
+			return u, nil
+		}
+	}
+	return domain.User{}, domain.NewDomainError(domain.ErrNotFound, "user not found")
+}
+
+func (m *memUsers) GetActiveTeamMembersExcept(_ context.Context, team domain.TeamName, exclude []domain.UserID) ([]domain.User, error) {
+	var result []domain.User
+	for _, u := range m.users {
+		if u.TeamName == team && u.IsActive && !slices.Contains(exclude, u.ID) {
+			result = append(result, u)
+		}
+	}
+	return result, nil
+}
+
+// memPRs keeps pull requests in memory and derives reviewer loads from
+// their open reviews.
+type memPRs struct {
+	PullRequestRepository
+
+	prs      map[domain.PullRequestID]domain.PullRequest
+	files    map[domain.PullRequestID][]string
+	replaced []domain.ReviewAssignment
+}
+
+func (m *memPRs) GetChangedFiles(_ context.Context, id domain.PullRequestID) ([]string, error) {
+	return m.files[id], nil
+}
+
+func (m *memPRs) GetByID(_ context.Context, id domain.PullRequestID) (domain.PullRequest, error) {
+	pr, ok := m.prs[id]
+	if !ok {
+		return domain.PullRequest{}, domain.NewDomainError(domain.ErrNotFound, "pull request not found")
+	}
+	return pr, nil
+}
+
+func (m *memPRs) GetOpenPRIDsByReviewer(_ context.Context, reviewerID domain.UserID) ([]domain.PullRequestID, error) {
+	var ids []domain.PullRequestID
+	for id, pr := range m.prs {
+		if pr.Status == domain.PRStatusOpen && slices.Contains(pr.AssignedReviewers, reviewerID) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+func (m *memPRs) GetReviewerLoads(_ context.Context, reviewerIDs []domain.UserID) (map[domain.UserID]domain.ReviewerLoad, error) {
+	loads := make(map[domain.UserID]domain.ReviewerLoad, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		loads[id] = domain.ReviewerLoad{UserID: id}
+	}
+	for _, pr := range m.prs {
+		if pr.Status != domain.PRStatusOpen {
+			continue
+		}
+		for _, id := range pr.AssignedReviewers {
+			if l, ok := loads[id]; ok {
+				l.OpenReviews++
+				loads[id] = l
+			}
+		}
+	}
+	return loads, nil
+}
+
+func (m *memPRs) ReplaceReviewer(_ context.Context, prID domain.PullRequestID, oldReviewerID, newReviewerID domain.UserID, reason domain.AssignmentReason, _ domain.UserID) (domain.PullRequest, error) {
+	pr := m.prs[prID]
+	pr.AssignedReviewers = slices.Clone(pr.AssignedReviewers)
+	i := slices.Index(pr.AssignedReviewers, oldReviewerID)
+	pr.AssignedReviewers[i] = newReviewerID
+	m.prs[prID] = pr
+	m.replaced = append(m.replaced, domain.ReviewAssignment{PullRequestID: prID, ReviewerID: newReviewerID, Reason: reason})
+	return pr, nil
+}
+
+func capacity(n int) *int { return &n }
+
+func newTestPRService(users *memUsers, prs *memPRs, teams *memTeams) *PRService {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewPRService(logger, users, prs, &seqRand{vals: []int{0}}, teams, nil)
+}
+
+type fakeAbsences struct {
+	AbsenceRepository
+
+	started    []domain.Absence
+	reassigned map[int64]time.Time
+	retryAt    map[int64]time.Time
+}
+
+func (f *fakeAbsences) ListStartedForReassign(_ context.Context, now time.Time, limit int) ([]domain.Absence, error) {
+	var result []domain.Absence
+	for _, a := range f.started {
+		if _, done := f.reassigned[a.ID]; done || a.StartsAt.After(now) || !a.EndsAt.After(now) {
+			continue
+		}
+		if at, ok := f.retryAt[a.ID]; ok && at.After(now) {
+			continue
+		}
+		result = append(result, a)
+	}
+	return result[:min(limit, len(result))], nil
+}
+
+func (f *fakeAbsences) PostponeReassign(_ context.Context, id int64, until time.Time) error {
+	f.retryAt[id] = until
+	return nil
+}
+
+func (f *fakeAbsences) MarkReassigned(_ context.Context, id int64, at time.Time) error {
+	f.reassigned[id] = at
+	return nil
+}
+
+func TestReassignStartedKeepsAbsenceUntilAllReviewsHandedOver(t *testing.T) {
+	now := time.Date(2025, 12, 22, 9, 0, 0, 0, time.UTC)
+
+	users := &memUsers{users: []domain.User{
+		{ID: "u1", TeamName: "backend", IsActive: true},
+		{ID: "u2", TeamName: "backend", IsActive: true},
+		{ID: "u3", TeamName: "backend", IsActive: true},
+		{ID: "u4", TeamName: "backend", IsActive: true, MaxOpenReviews: capacity(1)},
+	}}
+	prs := &memPRs{prs: map[domain.PullRequestID]domain.PullRequest{
+		// u3 can take pr-1 over.
+		"pr-1": {ID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []domain.UserID{"u2"}},
+		// Everybody but the full u4 is already on pr-2.
+		"pr-2": {ID: "pr-2", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []domain.UserID{"u2", "u3"}},
+		"pr-3": {ID: "pr-3", AuthorID: "u3", Status: domain.PRStatusOpen, AssignedReviewers: []domain.UserID{"u4"}},
+	}}
+	absences := &fakeAbsences{
+		started:    []domain.Absence{{ID: 7, UserID: "u2", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(72 * time.Hour), ReassignReviews: true}},
+		reassigned: map[int64]time.Time{},
+		retryAt:    map[int64]time.Time{},
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewAbsenceService(logger, absences, users, prs, newTestPRService(users, prs, &memTeams{}))
+
+	done, err := svc.ReassignStarted(context.Background(), now)
+	if err != nil {
+		t.Fatalf("ReassignStarted: %v", err)
+	}
+	if done != 0 {
+		t.Errorf("done = %d, want 0 while pr-2 is left", done)
+	}
+	if _, ok := absences.reassigned[7]; ok {
+		t.Errorf("absence marked reassigned with a review left")
+	}
+	if got := prs.prs["pr-1"].AssignedReviewers; !slices.Equal(got, []domain.UserID{"u3"}) {
+		t.Errorf("pr-1 reviewers = %v, want [u3]", got)
+	}
+
+	// pr-3 is merged, which frees u4 up for pr-2 on the next run.
+	pr3 := prs.prs["pr-3"]
+	pr3.Status = domain.PRStatusMerged
+	prs.prs["pr-3"] = pr3
+
+	// The absence is set aside until the retry delay has passed.
+	if done, err := svc.ReassignStarted(context.Background(), now.Add(time.Minute)); err != nil || done != 0 {
+		t.Fatalf("ReassignStarted before retry = %d, %v; want 0, nil", done, err)
+	}
+	if got := prs.prs["pr-2"].AssignedReviewers; !slices.Equal(got, []domain.UserID{"u2", "u3"}) {
+		t.Errorf("pr-2 reviewers = %v before retry, want [u2 u3]", got)
+	}
+
+	later := now.Add(absenceRetryDelay)
+	if done, err := svc.ReassignStarted(context.Background(), later); err != nil || done != 1 {
+		t.Fatalf("second ReassignStarted = %d, %v; want 1, nil", done, err)
+	}
+	if got := prs.prs["pr-2"].AssignedReviewers; !slices.Equal(got, []domain.UserID{"u4", "u3"}) {
+		t.Errorf("pr-2 reviewers = %v, want [u4 u3]", got)
+	}
+	if at, ok := absences.reassigned[7]; !ok || !at.Equal(later) {
+		t.Errorf("reassigned_at = %v, %v; want %v", at, ok, later)
+	}
+	for _, a := range prs.replaced {
+		if a.Reason != domain.AssignmentReasonAbsence {
+			t.Errorf("%s: reason = %s, want %s", a.PullRequestID, a.Reason, domain.AssignmentReasonAbsence)
+		}
+	}
+}
+
+func TestReassignStartedIgnoresReviewsGoneMeanwhile(t *testing.T) {
+	now := time.Date(2025, 12, 22, 9, 0, 0, 0, time.UTC)
+
+	users := &memUsers{users: []domain.User{{ID: "u2", TeamName: "backend", IsActive: true}}}
+	// The absent user is listed on pr-1, but it is closed before the
+	// handover, so nothing is left to retry.
+	prs := &memPRs{prs: map[domain.PullRequestID]domain.PullRequest{}}
+	absences := &fakeAbsences{
+		started:    []domain.Absence{{ID: 1, UserID: "u2", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour), ReassignReviews: true}},
+		reassigned: map[int64]time.Time{},
+		retryAt:    map[int64]time.Time{},
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewAbsenceService(logger, absences, users, &closingPRs{memPRs: prs, closes: "pr-1"}, newTestPRService(users, prs, &memTeams{}))
+
+	if done, err := svc.ReassignStarted(context.Background(), now); err != nil || done != 1 {
+		t.Fatalf("ReassignStarted = %d, %v; want 1, nil", done, err)
+	}
+}
+
+func TestReassignStartedDoesNotLetStuckAbsencesStarveNewerOnes(t *testing.T) {
+	now := time.Date(2025, 12, 22, 9, 0, 0, 0, time.UTC)
+
+	// Only the author is left to take over pr-1 from u1, so the absences of
+	// u1 can never be handled; u2 has no reviews to hand over.
+	users := &memUsers{users: []domain.User{
+		{ID: "u1", TeamName: "backend", IsActive: true},
+		{ID: "u9", TeamName: "backend", IsActive: true},
+		{ID: "u2", TeamName: "frontend", IsActive: true},
+	}}
+	prs := &memPRs{prs: map[domain.PullRequestID]domain.PullRequest{
+		"pr-1": {ID: "pr-1", AuthorID: "u9", Status: domain.PRStatusOpen, AssignedReviewers: []domain.UserID{"u1"}},
+	}}
+	absences := &fakeAbsences{reassigned: map[int64]time.Time{}, retryAt: map[int64]time.Time{}}
+	for i := range absenceBatchSize {
+		absences.started = append(absences.started, domain.Absence{ID: int64(i + 1), UserID: "u1", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour), ReassignReviews: true})
+	}
+	fresh := domain.Absence{ID: 100, UserID: "u2", StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour), ReassignReviews: true}
+	absences.started = append(absences.started, fresh)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewAbsenceService(logger, absences, users, prs, newTestPRService(users, prs, &memTeams{}))
+
+	if done, err := svc.ReassignStarted(context.Background(), now); err != nil || done != 0 {
+		t.Fatalf("first ReassignStarted = %d, %v; want 0, nil", done, err)
+	}
+	if done, err := svc.ReassignStarted(context.Background(), now.Add(time.Minute)); err != nil || done != 1 {
+		t.Fatalf("second ReassignStarted = %d, %v; want 1, nil", done, err)
+	}
+	if _, ok := absences.reassigned[fresh.ID]; !ok {
+		t.Errorf("absence of u2 not reassigned behind the stuck ones")
+	}
+}
+
+// closingPRs lists a review that is no longer open by the time the PR is
+// loaded.
+type closingPRs struct {
+	*memPRs
+	closes domain.PullRequestID
+}
+
+func (c *closingPRs) GetOpenPRIDsByReviewer(_ context.Context, _ domain.UserID) ([]domain.PullRequestID, error) {
+	c.prs[c.closes] = domain.PullRequest{ID: c.closes, AuthorID: "u1", Status: domain.PRStatusClosed, AssignedReviewers: []domain.UserID{"u2"}}
+	return []domain.PullRequestID{c.closes}, nil
+}
+
+func TestAbsenceValidateFields(t *testing.T) {
+	start := time.Date(2025, 12, 22, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		absence domain.Absence
+		field   string
+	}{
+		{"no user", domain.Absence{StartsAt: start, EndsAt: start.Add(time.Hour)}, "user_id"},
+		{"no start", domain.Absence{UserID: "u1", EndsAt: start}, "starts_at"},
+		{"no end", domain.Absence{UserID: "u1", StartsAt: start}, "ends_at"},
+		{"ends before start", domain.Absence{UserID: "u1", StartsAt: start, EndsAt: start.Add(-time.Hour)}, "ends_at"},
+	}
+
+	for _, tt := range tests {
+		err := tt.absence.Validate()
+		var ve *domain.ValidationError
+		if !errors.As(err, &ve) || ve.Field != tt.field {
+			t.Errorf("%s: Validate() = %v, want a validation error on %s", tt.name, err, tt.field)
+		}
+	}
+}
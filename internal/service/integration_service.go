@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+type IntegrationService struct {
+	logger   *slog.Logger
+	mappings ExternalUserRepository
+	prs      *PRService
+}
+
+func NewIntegrationService(logger *slog.Logger, mappings ExternalUserRepository, prs *PRService) *IntegrationService {
+	return &IntegrationService{
+		logger:   logger,
+		mappings: mappings,
+		prs:      prs,
+	}
+}
+
+func (s *IntegrationService) SetUserMappings(ctx context.Context, mappings []domain.ExternalUserMapping) error {
+	for _, m := range mappings {
+		if err := m.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := s.mappings.UpsertMappings(ctx, mappings); err != nil {
+		s.logger.Error("upsert user mappings", slog.Int("count", len(mappings)), slog.Any("err", err))
+		return err
+	}
+	return nil
+}
+
+func (s *IntegrationService) ListUserMappings(ctx context.Context, provider domain.ExternalProvider) ([]domain.ExternalUserMapping, error) {
+	if err := provider.Validate(); err != nil {
+		return nil, err
+	}
+	return s.mappings.ListMappings(ctx, provider)
+}
+
+// HandlePREvent applies a provider pull request event. It returns false when
+// the event does not affect the service and was ignored.
+func (s *IntegrationService) HandlePREvent(ctx context.Context, ev domain.ExternalPREvent) (domain.PullRequest, bool, error) {
+	id := ev.PullRequestID()
+
+	var (
+		pr  domain.PullRequest
+		err error
+	)
+
+	actor, err := s.resolveActor(ctx, ev)
+	if err != nil {
+		return domain.PullRequest{}, false, err
+	}
+
+	switch ev.Action {
+	case domain.ExternalPROpened:
+		pr, err = s.create(ctx, ev)
+		if domain.IsDomainError(err, domain.ErrPRExists) {
+			return domain.PullRequest{}, false, nil
+		}
+	case domain.ExternalPRReopened:
+		pr, _, err = s.prs.Reopen(ctx, id, actor)
+		if domain.IsDomainError(err, domain.ErrNotFound) {
+			pr, err = s.create(ctx, ev)
+		}
+	case domain.ExternalPRReadyForReview:
+		pr, _, err = s.prs.MarkReady(ctx, id, actor)
+		if domain.IsDomainError(err, domain.ErrNotFound) {
+			pr, err = s.create(ctx, ev)
+		}
+	case domain.ExternalPRClosed:
+		if ev.Merged {
+			mergedAt := time.Now().UTC()
+			if ev.MergedAt != nil {
+				mergedAt = ev.MergedAt.UTC()
+			}
+			pr, err = s.prs.MarkMerged(ctx, id, mergedAt, actor)
+		} else {
+			pr, err = s.prs.Close(ctx, id, actor)
+		}
+		// PRs opened before the integration was set up are not tracked.
+		if domain.IsDomainError(err, domain.ErrNotFound) {
+			return domain.PullRequest{}, false, nil
+		}
+	default:
+		return domain.PullRequest{}, false, nil
+	}
+
+	if err != nil {
+		s.logger.Error("handle external pr event", slog.String("provider", string(ev.Provider)), slog.String("action", string(ev.Action)), slog.String("pr_id", string(id)), slog.Any("err", err))
+		return domain.PullRequest{}, false, err
+	}
+
+	return pr, true, nil
+}
+
+// resolveAuthor prefers the provider user id and falls back to the login,
+// which is only set when the event says whose it is.
+func (s *IntegrationService) resolveAuthor(ctx context.Context, ev domain.ExternalPREvent) (domain.UserID, error) {
+	if ev.AuthorExternalID != 0 {
+		authorID, err := s.mappings.ResolveExternalID(ctx, ev.Provider, ev.AuthorExternalID)
+		if ev.AuthorLogin == "" || !domain.IsDomainError(err, domain.ErrNotFound) {
+			return authorID, err
+		}
+	}
+	if ev.AuthorLogin == "" {
+		return "", domain.NewDomainError(domain.ErrNotFound, fmt.Sprintf("%s pull request author is not mapped to a user", ev.Provider))
+	}
+	return s.mappings.ResolveLogin(ctx, ev.Provider, ev.AuthorLogin)
+}
+
+// resolveActor maps the provider user who triggered the event. Actors without
+// a mapping are recorded as unknown rather than failing the event.
+func (s *IntegrationService) resolveActor(ctx context.Context, ev domain.ExternalPREvent) (domain.UserID, error) {
+	if ev.ActorLogin == "" {
+		return "", nil
+	}
+
+	actor, err := s.mappings.ResolveLogin(ctx, ev.Provider, ev.ActorLogin)
+	if domain.IsDomainError(err, domain.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		s.logger.Error("resolve webhook actor", slog.String("provider", string(ev.Provider)), slog.String("login", ev.ActorLogin), slog.Any("err", err))
+		return "", err
+	}
+
+	return actor, nil
+}
+
+func (s *IntegrationService) create(ctx context.Context, ev domain.ExternalPREvent) (domain.PullRequest, error) {
+	authorID, err := s.resolveAuthor(ctx, ev)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	pr, _, err := s.prs.Create(ctx, CreatePRInput{
+		ID:       ev.PullRequestID(),
+		Name:     ev.Title,
+		AuthorID: authorID,
+		Draft:    ev.Draft,
+	})
+	return pr, err
+}
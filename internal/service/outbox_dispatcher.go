@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+const (
+	outboxBatchSize     = 50
+	outboxLease         = time.Minute
+	outboxPurgeInterval = time.Hour
+)
+
+// OutboxDispatcher hands committed events to sinks. Each sink that accepts an
+// event is recorded, so a retry only goes to the sinks that failed. An event
+// is marked dispatched once every sink accepted it and dead after
+// domain.MaxOutboxAttempts.
+type OutboxDispatcher struct {
+	logger *slog.Logger
+	outbox OutboxRepository
+	sinks  []OutboxSink
+}
+
+func NewOutboxDispatcher(logger *slog.Logger, outbox OutboxRepository, sinks ...OutboxSink) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		logger: logger,
+		outbox: outbox,
+		sinks:  sinks,
+	}
+}
+
+// Run dispatches due events every interval until ctx is cancelled. Once an
+// hour it deletes events dispatched more than retention ago.
+func (d *OutboxDispatcher) Run(ctx context.Context, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastPurge time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if time.Since(lastPurge) >= outboxPurgeInterval {
+				lastPurge = time.Now()
+				if _, err := d.Purge(ctx, retention); err != nil {
+					d.logger.Error("purge outbox", slog.Any("err", err))
+				}
+			}
+			for {
+				n, err := d.DispatchDue(ctx)
+				if err != nil {
+					d.logger.Error("dispatch outbox", slog.Any("err", err))
+				}
+				if err != nil || n < outboxBatchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// DispatchDue handles one batch of due events and returns its size.
+func (d *OutboxDispatcher) DispatchDue(ctx context.Context) (int, error) {
+	events, err := d.outbox.ClaimDue(ctx, outboxBatchSize, outboxLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, e := range events {
+		if err := d.dispatch(ctx, e); err != nil {
+			var retryAt *time.Time
+			if e.Attempts < domain.MaxOutboxAttempts {
+				t := time.Now().Add(domain.OutboxBackoff(e.Attempts))
+				retryAt = &t
+			}
+
+			level := slog.LevelWarn
+			if retryAt == nil {
+				level = slog.LevelError
+			}
+			d.logger.Log(ctx, level, "outbox event not dispatched",
+				slog.Int64("event_id", e.ID),
+				slog.String("event_type", string(e.Type)),
+				slog.Int("attempt", e.Attempts),
+				slog.Bool("will_retry", retryAt != nil),
+				slog.Any("err", err),
+			)
+
+			if err := d.outbox.MarkFailed(ctx, e.ID, err.Error(), retryAt); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		if err := d.outbox.MarkDispatched(ctx, e.ID); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(events), nil
+}
+
+// Purge deletes events dispatched more than retention ago.
+func (d *OutboxDispatcher) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	n, err := d.outbox.PurgeDispatched(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, err
+	}
+	if n > 0 {
+		d.logger.Info("purged outbox events", slog.Int64("count", n))
+	}
+	return n, nil
+}
+
+// dispatch hands e to the sinks that have not accepted it yet.
+func (d *OutboxDispatcher) dispatch(ctx context.Context, e domain.OutboxEvent) error {
+	var errs []error
+	for _, sink := range d.sinks {
+		name := sink.Name()
+		if slices.Contains(e.DeliveredSinks, name) {
+			continue
+		}
+		if err := sink.Handle(ctx, e); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		if err := d.outbox.MarkSinkDelivered(ctx, e.ID, name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
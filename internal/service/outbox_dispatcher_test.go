@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+	"github.com/freeholder/pr-reviewer-service/internal/outbox"
+)
+
+type fakeOutbox struct {
+	pending    []domain.OutboxEvent
+	dispatched []int64
+	failed     []int64
+	dead       []int64
+	delivered  map[int64][]string
+	purgedTo   time.Time
+}
+
+func (f *fakeOutbox) ClaimDue(_ context.Context, limit int, _ time.Duration) ([]domain.OutboxEvent, error) {
+	n := min(limit, len(f.pending))
+	batch := f.pending[:n]
+	f.pending = f.pending[n:]
+	for i := range batch {
+		batch[i].Attempts++
+		batch[i].DeliveredSinks = f.delivered[batch[i].ID]
+	}
+	return batch, nil
+}
+
+func (f *fakeOutbox) MarkSinkDelivered(_ context.Context, id int64, sink string) error {
+	if f.delivered == nil {
+		f.delivered = make(map[int64][]string)
+	}
+	f.delivered[id] = append(f.delivered[id], sink)
+	return nil
+}
+
+func (f *fakeOutbox) PurgeDispatched(_ context.Context, before time.Time) (int64, error) {
+	f.purgedTo = before
+	return 0, nil
+}
+
+func (f *fakeOutbox) MarkDispatched(_ context.Context, id int64) error {
+	f.dispatched = append(f.dispatched, id)
+	return nil
+}
+
+func (f *fakeOutbox) MarkFailed(_ context.Context, id int64, _ string, retryAt *time.Time) error {
+	if retryAt == nil {
+		f.dead = append(f.dead, id)
+		return nil
+	}
+	f.failed = append(f.failed, id)
+	return nil
+}
+
+type flakySink struct {
+	failures int
+}
+
+func (s *flakySink) Name() string { return "flaky" }
+
+func (s *flakySink) Handle(context.Context, domain.OutboxEvent) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("unavailable")
+	}
+	return nil
+}
+
+func TestOutboxDispatcherRetriesUntilAllSinksSucceed(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeOutbox{pending: []domain.OutboxEvent{{ID: 1, Type: domain.EventPRCreated}}}
+	memory := outbox.NewMemorySink()
+	d := NewOutboxDispatcher(slog.New(slog.NewTextHandler(io.Discard, nil)), repo, memory, &flakySink{failures: 1})
+
+	if _, err := d.DispatchDue(ctx); err != nil {
+		t.Fatalf("first dispatch: %v", err)
+	}
+	if len(repo.failed) != 1 || len(repo.dispatched) != 0 {
+		t.Fatalf("after failure: failed=%v dispatched=%v", repo.failed, repo.dispatched)
+	}
+
+	repo.pending = append(repo.pending, domain.OutboxEvent{ID: 1, Type: domain.EventPRCreated, Attempts: 1})
+	if _, err := d.DispatchDue(ctx); err != nil {
+		t.Fatalf("second dispatch: %v", err)
+	}
+	if len(repo.dispatched) != 1 || repo.dispatched[0] != 1 {
+		t.Fatalf("dispatched = %v, want [1]", repo.dispatched)
+	}
+
+	// The healthy sink accepted the event on the first attempt and is not
+	// handed it again.
+	if got := len(memory.Events()); got != 1 {
+		t.Fatalf("memory sink got %d events, want 1", got)
+	}
+}
+
+func TestOutboxDispatcherMarksEventDeadAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeOutbox{pending: []domain.OutboxEvent{{ID: 7, Type: domain.EventPRMerged, Attempts: domain.MaxOutboxAttempts - 1}}}
+	d := NewOutboxDispatcher(slog.New(slog.NewTextHandler(io.Discard, nil)), repo, &flakySink{failures: 1})
+
+	if _, err := d.DispatchDue(ctx); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	if len(repo.dead) != 1 || repo.dead[0] != 7 || len(repo.failed) != 0 {
+		t.Fatalf("dead=%v failed=%v, want the event dead", repo.dead, repo.failed)
+	}
+}
+
+func TestOutboxDispatcherPurgesBeforeRetention(t *testing.T) {
+	repo := &fakeOutbox{}
+	d := NewOutboxDispatcher(slog.New(slog.NewTextHandler(io.Discard, nil)), repo)
+
+	if _, err := d.Purge(context.Background(), 24*time.Hour); err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if age := time.Since(repo.purgedTo); age < 24*time.Hour || age > 24*time.Hour+time.Minute {
+		t.Fatalf("purged events older than %v, want 24h", age)
+	}
+}
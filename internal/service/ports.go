@@ -10,20 +10,98 @@ import (
 type TeamRepository interface {
 	CreateTeam(ctx context.Context, team domain.Team) error
 	GetTeamByName(ctx context.Context, name domain.TeamName) (domain.Team, error)
+	GetReviewerStrategy(ctx context.Context, name domain.TeamName) (domain.ReviewerStrategy, error)
+	SetReviewerStrategy(ctx context.Context, name domain.TeamName, strategy domain.ReviewerStrategy) error
+	GetSettings(ctx context.Context, name domain.TeamName) (domain.TeamSettings, error)
+	UpsertSettings(ctx context.Context, settings domain.TeamSettings) error
+	GetFallbackTeams(ctx context.Context, name domain.TeamName) ([]domain.TeamName, error)
+	SetFallbackTeams(ctx context.Context, name domain.TeamName, fallbacks []domain.TeamName) error
+	GetCodeowners(ctx context.Context, name domain.TeamName) (domain.Codeowners, error)
+	SetCodeowners(ctx context.Context, co domain.Codeowners) (domain.Codeowners, error)
 }
 
 type UserRepository interface {
 	UpdateUsers(ctx context.Context, users []domain.User) error
 	GetUserByID(ctx context.Context, id domain.UserID) (domain.User, error)
 	SetUserActive(ctx context.Context, id domain.UserID, isActive bool) (domain.User, error)
+	SetSlackHandle(ctx context.Context, id domain.UserID, handle string) (domain.User, error)
+	SetEmail(ctx context.Context, id domain.UserID, email string) (domain.User, error)
+	SetMaxOpenReviews(ctx context.Context, id domain.UserID, limit *int) (domain.User, error)
+	SetSkills(ctx context.Context, id domain.UserID, skills []string) (domain.User, error)
 	GetActiveTeamMembersExcept(ctx context.Context, teamName domain.TeamName, exclude []domain.UserID) ([]domain.User, error)
 }
 
+type AbsenceRepository interface {
+	CreateAbsence(ctx context.Context, a domain.Absence) (domain.Absence, error)
+	ListAbsences(ctx context.Context, userID domain.UserID, now time.Time) ([]domain.Absence, error)
+	ListStartedForReassign(ctx context.Context, now time.Time, limit int) ([]domain.Absence, error)
+	MarkReassigned(ctx context.Context, id int64, at time.Time) error
+	PostponeReassign(ctx context.Context, id int64, until time.Time) error
+	DeleteAbsence(ctx context.Context, id int64) error
+}
+
 type PullRequestRepository interface {
 	Create(ctx context.Context, pr domain.PullRequest) error
 	GetByID(ctx context.Context, id domain.PullRequestID) (domain.PullRequest, error)
-	SetMerged(ctx context.Context, id domain.PullRequestID, mergedAt time.Time) (domain.PullRequest, error)
-	ReplaceReviewer(ctx context.Context, prID domain.PullRequestID, oldReviewerID, newReviewerID domain.UserID) (domain.PullRequest, error)
+	SetMerged(ctx context.Context, id domain.PullRequestID, mergedAt time.Time, actor domain.UserID, details string, policy *domain.TeamSettings) (domain.PullRequest, error)
+	SetClosed(ctx context.Context, id domain.PullRequestID, closedAt time.Time, actor domain.UserID) (domain.PullRequest, error)
+	SetOpen(ctx context.Context, id domain.PullRequestID, newReviewers []domain.UserID, actor domain.UserID) (domain.PullRequest, error)
+	ReplaceReviewer(ctx context.Context, prID domain.PullRequestID, oldReviewerID, newReviewerID domain.UserID, reason domain.AssignmentReason, actor domain.UserID) (domain.PullRequest, error)
+	ListAssignments(ctx context.Context, prID domain.PullRequestID) ([]domain.ReviewAssignment, error)
+	ListEvents(ctx context.Context, prID domain.PullRequestID) ([]domain.PREvent, error)
+	SetReviewState(ctx context.Context, prID domain.PullRequestID, reviewerID domain.UserID, state domain.ReviewState, reviewedAt time.Time) (domain.PullRequest, error)
 	ListByReviewer(ctx context.Context, reviewerID domain.UserID) ([]domain.PullRequest, error)
 	GetOpenPRIDsByReviewer(ctx context.Context, reviewerID domain.UserID) ([]domain.PullRequestID, error)
+	GetChangedFiles(ctx context.Context, id domain.PullRequestID) ([]string, error)
+	GetReviewerLoads(ctx context.Context, reviewerIDs []domain.UserID) (map[domain.UserID]domain.ReviewerLoad, error)
+	ListPendingReviews(ctx context.Context, now time.Time, limit int) ([]domain.PendingReview, error)
+	MarkReminded(ctx context.Context, prID domain.PullRequestID, reviewerID domain.UserID, at time.Time) error
+	MarkEscalated(ctx context.Context, prID domain.PullRequestID, reviewerID domain.UserID, at time.Time) error
+	PostponeSLA(ctx context.Context, prID domain.PullRequestID, reviewerID domain.UserID, until time.Time) error
+}
+
+type BacklogRepository interface {
+	Enqueue(ctx context.Context, prID domain.PullRequestID, missing int) error
+	ListBacklog(ctx context.Context, team domain.TeamName) ([]domain.BacklogEntry, error)
+	FillBacklog(ctx context.Context, prID domain.PullRequestID, reviewers []domain.UserID) ([]domain.UserID, error)
+	MarkBacklogAttempt(ctx context.Context, prID domain.PullRequestID) error
+}
+
+type ExternalUserRepository interface {
+	UpsertMappings(ctx context.Context, mappings []domain.ExternalUserMapping) error
+	ListMappings(ctx context.Context, provider domain.ExternalProvider) ([]domain.ExternalUserMapping, error)
+	ResolveLogin(ctx context.Context, provider domain.ExternalProvider, login string) (domain.UserID, error)
+	ResolveExternalID(ctx context.Context, provider domain.ExternalProvider, externalID int64) (domain.UserID, error)
+}
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, s domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, id int64) (domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, s domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, subscriptionID int64, status domain.WebhookDeliveryStatus, limit int) ([]domain.WebhookDelivery, error)
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]domain.WebhookDispatch, error)
+	MarkDelivered(ctx context.Context, id int64, responseStatus int) error
+	MarkFailed(ctx context.Context, id int64, responseStatus int, errMsg string, retryAt *time.Time) error
+	Redeliver(ctx context.Context, id int64) (domain.WebhookDelivery, error)
+}
+
+type WebhookSender interface {
+	Send(ctx context.Context, d domain.WebhookDispatch) (int, error)
+}
+
+type OutboxRepository interface {
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]domain.OutboxEvent, error)
+	MarkDispatched(ctx context.Context, id int64) error
+	MarkSinkDelivered(ctx context.Context, id int64, sink string) error
+	MarkFailed(ctx context.Context, id int64, errMsg string, retryAt *time.Time) error
+	PurgeDispatched(ctx context.Context, before time.Time) (int64, error)
+}
+
+// OutboxSink receives every committed domain event at least once, so
+// handling the same event twice must be harmless.
+type OutboxSink interface {
+	Name() string
+	Handle(ctx context.Context, e domain.OutboxEvent) error
 }
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+// enqueueUnfilled puts a PR with empty reviewer slots into the assignment
+// backlog. The PR itself is already stored, so a failure is only logged.
+func (s *PRService) enqueueUnfilled(ctx context.Context, id domain.PullRequestID, report AssignmentReport) {
+	missing := report.Unfilled()
+	if missing == 0 {
+		return
+	}
+
+	log := s.logger.With(slog.String("pr_id", string(id)), slog.Int("missing", missing), slog.Int("at_capacity", len(report.AtCapacity)))
+
+	if err := s.backlog.Enqueue(ctx, id, missing); err != nil {
+		log.Error("enqueue pr into assignment backlog", slog.Any("err", err))
+		return
+	}
+	log.Info("pr added to assignment backlog")
+}
+
+// ListBacklog returns open PRs still missing reviewers; an empty team name
+// lists all teams.
+func (s *PRService) ListBacklog(ctx context.Context, team domain.TeamName) ([]domain.BacklogEntry, error) {
+	if team != "" {
+		if _, err := s.teams.GetSettings(ctx, team); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := s.backlog.ListBacklog(ctx, team)
+	if err != nil {
+		s.logger.Error("list assignment backlog", slog.String("team", string(team)), slog.Any("err", err))
+		return nil, err
+	}
+	return entries, nil
+}
+
+// RunBacklog periodically retries the backlog of every team. Events only
+// cover members becoming active; the sweep also picks up capacity freed by
+// merged or closed PRs, raised limits and absences that ended.
+func (s *PRService) RunBacklog(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.FillAllBacklogs(ctx); err != nil {
+				s.logger.Error("fill assignment backlog", slog.Any("err", err))
+			}
+		}
+	}
+}
+
+// FillAllBacklogs retries the backlog of every team that has one and returns
+// how many reviewers were assigned. A failing team does not stop the others.
+func (s *PRService) FillAllBacklogs(ctx context.Context) (int, error) {
+	entries, err := s.backlog.ListBacklog(ctx, "")
+	if err != nil {
+		return 0, err
+	}
+
+	var teams []domain.TeamName
+	for _, e := range entries {
+		if !slices.Contains(teams, e.TeamName) {
+			teams = append(teams, e.TeamName)
+		}
+	}
+
+	filled := 0
+	for _, team := range teams {
+		n, err := s.FillBacklog(ctx, team)
+		filled += n
+		if err != nil {
+			s.logger.Error("fill team backlog", slog.String("team", string(team)), slog.Any("err", err))
+		}
+	}
+	return filled, nil
+}
+
+// FillBacklog retries reviewer selection for the team's backlog and returns
+// how many reviewers were assigned. Selection goes through the same pipeline
+// as a new PR, so code owners, skills and fallback teams are honoured.
+func (s *PRService) FillBacklog(ctx context.Context, team domain.TeamName) (int, error) {
+	if team == "" {
+		return 0, domain.NewValidationError("team_name", "must not be empty")
+	}
+
+	entries, err := s.backlog.ListBacklog(ctx, team)
+	if err != nil {
+		return 0, err
+	}
+
+	filled := 0
+	for _, e := range entries {
+		chosen, err := s.selectBacklogReviewers(ctx, e)
+		if err == nil && len(chosen) > 0 {
+			// Another fill may have got there first; only the slots
+			// still missing are taken.
+			chosen, err = s.backlog.FillBacklog(ctx, e.PullRequestID, chosen)
+		}
+
+		var de *domain.DomainError
+		if err != nil && !errors.As(err, &de) {
+			return filled, err
+		}
+
+		// Nobody free, or the picked reviewers filled up meanwhile.
+		if err != nil || len(chosen) == 0 {
+			if de != nil {
+				s.logger.Warn("backlog entry not filled", slog.String("pr_id", string(e.PullRequestID)), slog.String("code", string(de.Code)), slog.String("reason", de.Message))
+			}
+			if err := s.backlog.MarkBacklogAttempt(ctx, e.PullRequestID); err != nil {
+				return filled, err
+			}
+			continue
+		}
+		filled += len(chosen)
+
+		for _, id := range chosen {
+			s.logger.Info("backlog reviewer assigned", slog.String("pr_id", string(e.PullRequestID)), slog.String("user_id", string(id)))
+		}
+	}
+
+	return filled, nil
+}
+
+// selectBacklogReviewers picks up to the missing number of reviewers for a
+// backlog entry, in the order the selection pipeline ranks them.
+func (s *PRService) selectBacklogReviewers(ctx context.Context, e domain.BacklogEntry) ([]domain.UserID, error) {
+	pr, err := s.prs.GetByID(ctx, e.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pr.ChangedFiles, err = s.prs.GetChangedFiles(ctx, pr.ID); err != nil {
+		return nil, err
+	}
+
+	author, err := s.users.GetUserByID(ctx, e.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	sel, err := s.selectReviewers(ctx, author, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	chosen := sel.report.Reviewers
+	return chosen[:min(e.Missing, len(chosen))], nil
+}
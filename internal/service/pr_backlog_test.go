@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+// memBacklog keeps backlog entries in memory and assigns filled reviewers to
+// the PRs in prs, the way FillBacklog does in one transaction: reviewers
+// already on the PR are skipped and the rest cut to the missing slots.
+type memBacklog struct {
+	BacklogRepository
+
+	prs      *memPRs
+	entries  []domain.BacklogEntry
+	attempts []domain.PullRequestID
+}
+
+func (m *memBacklog) ListBacklog(_ context.Context, team domain.TeamName) ([]domain.BacklogEntry, error) {
+	var result []domain.BacklogEntry
+	for _, e := range m.entries {
+		if team == "" || e.TeamName == team {
+			e.AssignedReviewers = m.prs.prs[e.PullRequestID].AssignedReviewers
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (m *memBacklog) FillBacklog(_ context.Context, prID domain.PullRequestID, reviewers []domain.UserID) ([]domain.UserID, error) {
+	i := slices.IndexFunc(m.entries, func(e domain.BacklogEntry) bool { return e.PullRequestID == prID })
+	if i < 0 {
+		return nil, nil
+	}
+
+	pr := m.prs.prs[prID]
+	var assigned []domain.UserID
+	for _, id := range reviewers {
+		if len(assigned) < m.entries[i].Missing && !slices.Contains(pr.AssignedReviewers, id) {
+			assigned = append(assigned, id)
+		}
+	}
+	pr.AssignedReviewers = append(slices.Clone(pr.AssignedReviewers), assigned...)
+	m.prs.prs[prID] = pr
+
+	m.entries[i].Missing -= len(assigned)
+	if m.entries[i].Missing <= 0 {
+		m.entries = slices.Delete(m.entries, i, i+1)
+	}
+	return assigned, nil
+}
+
+func (m *memBacklog) MarkBacklogAttempt(_ context.Context, prID domain.PullRequestID) error {
+	m.attempts = append(m.attempts, prID)
+	return nil
+}
+
+func TestFillAllBacklogsUsesFallbackTeams(t *testing.T) {
+	users := &memUsers{users: []domain.User{
+		{ID: "u1", TeamName: "backend", IsActive: true},
+		{ID: "u2", TeamName: "backend", IsActive: true, MaxOpenReviews: capacity(1)},
+		{ID: "p1", TeamName: "platform", IsActive: true},
+		{ID: "f1", TeamName: "frontend", IsActive: true},
+	}}
+	prs := &memPRs{prs: map[domain.PullRequestID]domain.PullRequest{
+		"pr-1": {ID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen},
+		"pr-2": {ID: "pr-2", AuthorID: "f1", Status: domain.PRStatusOpen},
+		// Keeps u2 at their limit.
+		"pr-busy": {ID: "pr-busy", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []domain.UserID{"u2"}},
+	}}
+	backlog := &memBacklog{prs: prs, entries: []domain.BacklogEntry{
+		{PullRequestID: "pr-1", AuthorID: "u1", TeamName: "backend", Missing: 1},
+		{PullRequestID: "pr-2", AuthorID: "f1", TeamName: "frontend", Missing: 2},
+	}}
+	teams := &memTeams{fallbacks: map[domain.TeamName][]domain.TeamName{"backend": {"platform"}}}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewPRService(logger, users, prs, &seqRand{vals: []int{0}}, teams, backlog)
+
+	filled, err := svc.FillAllBacklogs(context.Background())
+	if err != nil {
+		t.Fatalf("FillAllBacklogs: %v", err)
+	}
+	if filled != 1 {
+		t.Errorf("filled = %d, want 1", filled)
+	}
+	if got := prs.prs["pr-1"].AssignedReviewers; !slices.Equal(got, []domain.UserID{"p1"}) {
+		t.Errorf("pr-1 reviewers = %v, want [p1] from the fallback team", got)
+	}
+	// frontend has nobody but the author and no fallbacks.
+	if want := []domain.PullRequestID{"pr-2"}; !slices.Equal(backlog.attempts, want) {
+		t.Errorf("attempts = %v, want %v", backlog.attempts, want)
+	}
+	if len(backlog.entries) != 1 || backlog.entries[0].PullRequestID != "pr-2" {
+		t.Errorf("backlog = %v, want only pr-2 left", backlog.entries)
+	}
+
+	// Once pr-busy is merged, u2 is free again, but pr-1 is already full.
+	busy := prs.prs["pr-busy"]
+	busy.Status = domain.PRStatusMerged
+	prs.prs["pr-busy"] = busy
+
+	if filled, err := svc.FillBacklog(context.Background(), "backend"); err != nil || filled != 0 {
+		t.Errorf("FillBacklog(backend) = %d, %v; want 0, nil", filled, err)
+	}
+}
+
+func TestFillBacklogTakesOnlyMissingSlots(t *testing.T) {
+	users := &memUsers{users: []domain.User{
+		{ID: "u1", TeamName: "backend", IsActive: true},
+		{ID: "u2", TeamName: "backend", IsActive: true},
+		{ID: "u3", TeamName: "backend", IsActive: true},
+		{ID: "u4", TeamName: "backend", IsActive: true},
+	}}
+	prs := &memPRs{prs: map[domain.PullRequestID]domain.PullRequest{
+		"pr-1": {ID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []domain.UserID{"u2"}},
+	}}
+	backlog := &memBacklog{prs: prs, entries: []domain.BacklogEntry{
+		{PullRequestID: "pr-1", AuthorID: "u1", TeamName: "backend", Missing: 1},
+	}}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewPRService(logger, users, prs, &seqRand{vals: []int{0}}, &memTeams{}, backlog)
+
+	filled, err := svc.FillBacklog(context.Background(), "backend")
+	if err != nil || filled != 1 {
+		t.Fatalf("FillBacklog = %d, %v; want 1, nil", filled, err)
+	}
+	if got := prs.prs["pr-1"].AssignedReviewers; len(got) != 2 || got[0] != "u2" || slices.Contains(got, "u1") {
+		t.Errorf("pr-1 reviewers = %v, want u2 and one more non-author", got)
+	}
+	if len(backlog.entries) != 0 {
+		t.Errorf("backlog = %v, want empty", backlog.entries)
+	}
+}
+
+// racingBacklog lets another fill take a slot between the selection and the
+// fill of the service.
+type racingBacklog struct {
+	*memBacklog
+	other domain.UserID
+}
+
+func (r *racingBacklog) FillBacklog(ctx context.Context, prID domain.PullRequestID, reviewers []domain.UserID) ([]domain.UserID, error) {
+	if _, err := r.memBacklog.FillBacklog(ctx, prID, []domain.UserID{r.other}); err != nil {
+		return nil, err
+	}
+	return r.memBacklog.FillBacklog(ctx, prID, reviewers)
+}
+
+func TestFillBacklogCountsOnlySlotsLeftAfterConcurrentFill(t *testing.T) {
+	users := &memUsers{users: []domain.User{
+		{ID: "u1", TeamName: "backend", IsActive: true},
+		{ID: "u2", TeamName: "backend", IsActive: true},
+		{ID: "u3", TeamName: "backend", IsActive: true},
+		{ID: "u4", TeamName: "backend", IsActive: true},
+	}}
+	prs := &memPRs{prs: map[domain.PullRequestID]domain.PullRequest{
+		"pr-1": {ID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen},
+	}}
+	backlog := &racingBacklog{
+		memBacklog: &memBacklog{prs: prs, entries: []domain.BacklogEntry{
+			{PullRequestID: "pr-1", AuthorID: "u1", TeamName: "backend", Missing: 2},
+		}},
+		other: "u4",
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewPRService(logger, users, prs, &seqRand{vals: []int{0}}, &memTeams{}, backlog)
+
+	filled, err := svc.FillBacklog(context.Background(), "backend")
+	if err != nil || filled != 1 {
+		t.Fatalf("FillBacklog = %d, %v; want 1, nil", filled, err)
+	}
+	if got := prs.prs["pr-1"].AssignedReviewers; len(got) != 2 || got[0] != "u4" {
+		t.Errorf("pr-1 reviewers = %v, want u4 and one more", got)
+	}
+	if len(backlog.entries) != 0 {
+		t.Errorf("backlog = %v, want empty", backlog.entries)
+	}
+}
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/freeholder/pr-reviewer-service/internal/codeowners"
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+// CodeOwnerAssignment is a reviewer picked because they own some of the
+// changed files of the PR. FallbackTeam is set when the owner was borrowed
+// from a fallback team.
+type CodeOwnerAssignment struct {
+	ReviewerID   domain.UserID
+	Paths        []string
+	FallbackTeam domain.TeamName
+
+	owner domain.User
+}
+
+type ownedFile struct {
+	path   string
+	owners []domain.User
+}
+
+// pickCodeOwners picks up to limit reviewers among available so that as many
+// owned files as possible get one of their owners, using the team strategy
+// to choose between owners of the same file. Files no home member can cover
+// are offered to the owners in the fallback teams, in their order. Unowned
+// files are ignored. In REQUIRE mode a file nobody can cover is an error.
+// loads holds the open reviews of available; borrowed owners' are added.
+func (s *PRService) pickCodeOwners(ctx context.Context, team domain.TeamName, files []string, available []domain.User, loads map[domain.UserID]domain.ReviewerLoad, exclude []domain.UserID, limit int) ([]CodeOwnerAssignment, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	rules, co, err := s.codeownerRules(ctx, team)
+	if err != nil || co.Content == "" {
+		return nil, err
+	}
+
+	owned, err := s.ownedFiles(ctx, rules, files, available)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.borrowOwners(ctx, team, rules, owned, loads, exclude); err != nil {
+		return nil, err
+	}
+
+	require := co.Mode == domain.CodeownersModeRequire
+
+	var picked []domain.User
+	for _, f := range owned {
+		if slices.ContainsFunc(f.owners, func(u domain.User) bool { return containsUser(picked, u.ID) }) {
+			continue
+		}
+		if len(f.owners) == 0 {
+			if require {
+				return nil, domain.NewDomainError(domain.ErrNoCandidate, "no available code owner for "+f.path)
+			}
+			continue
+		}
+		if len(picked) == limit {
+			if require {
+				return nil, domain.NewDomainError(domain.ErrNoCandidate, "not enough reviewer slots for the code owners of "+f.path)
+			}
+			continue
+		}
+
+		// Owners are either home members or members of the first fallback
+		// team that has any, so the strategy of their team decides.
+		ownerTeam := f.owners[0].TeamName
+		chosen, err := s.pickReviewers(ctx, ownerTeam, f.owners, loads, 1)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range chosen {
+			picked = append(picked, f.owners[slices.IndexFunc(f.owners, func(u domain.User) bool { return u.ID == id })])
+		}
+	}
+
+	result := make([]CodeOwnerAssignment, 0, len(picked))
+	for _, u := range picked {
+		a := CodeOwnerAssignment{ReviewerID: u.ID, owner: u}
+		if u.TeamName != team {
+			a.FallbackTeam = u.TeamName
+		}
+		for _, f := range owned {
+			if containsUser(f.owners, u.ID) {
+				a.Paths = append(a.Paths, f.path)
+			}
+		}
+		result = append(result, a)
+	}
+	return result, nil
+}
+
+// codeownerRules loads and parses the CODEOWNERS of the team. An empty
+// Content means the team has none.
+func (s *PRService) codeownerRules(ctx context.Context, team domain.TeamName) (codeowners.Ruleset, domain.Codeowners, error) {
+	co, err := s.teams.GetCodeowners(ctx, team)
+	if err != nil {
+		s.logger.Error("get team codeowners", slog.String("team", string(team)), slog.Any("err", err))
+		return codeowners.Ruleset{}, domain.Codeowners{}, err
+	}
+	if co.Content == "" {
+		return codeowners.Ruleset{}, co, nil
+	}
+
+	rules, err := codeowners.Parse(co.Content)
+	if err != nil {
+		return codeowners.Ruleset{}, domain.Codeowners{}, fmt.Errorf("parse codeowners of %s: %w", team, err)
+	}
+	return rules, co, nil
+}
+
+// borrowOwners fills in the owners of files no home member can cover from
+// the fallback teams: the members of the first team that owns the file.
+// Their loads are added to loads.
+func (s *PRService) borrowOwners(ctx context.Context, home domain.TeamName, rules codeowners.Ruleset, owned []ownedFile, loads map[domain.UserID]domain.ReviewerLoad, exclude []domain.UserID) error {
+	var orphans []string
+	for _, f := range owned {
+		if len(f.owners) == 0 {
+			orphans = append(orphans, f.path)
+		}
+	}
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	pools, err := s.fallbackPools(ctx, home, exclude)
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range pools {
+		maps.Copy(loads, pool.loads)
+		borrowed, err := s.ownedFiles(ctx, rules, orphans, pool.available)
+		if err != nil {
+			return err
+		}
+		for _, b := range borrowed {
+			i := slices.IndexFunc(owned, func(f ownedFile) bool { return f.path == b.path })
+			if len(owned[i].owners) == 0 {
+				owned[i].owners = b.owners
+			}
+		}
+	}
+	return nil
+}
+
+// ownerRequirement lists the changed files whose only code owner among the
+// reviewers of a PR is the one being replaced, under a REQUIRE CODEOWNERS.
+type ownerRequirement struct {
+	rules codeowners.Ruleset
+	files []string
+}
+
+// requiredOwnership returns what a replacement of reviewerID must own so
+// that a REQUIRE CODEOWNERS of the author's team stays satisfied. It is nil
+// when nothing has to be owned.
+func (s *PRService) requiredOwnership(ctx context.Context, pr domain.PullRequest, reviewerID domain.UserID) (*ownerRequirement, error) {
+	files, err := s.prs.GetChangedFiles(ctx, pr.ID)
+	if err != nil || len(files) == 0 {
+		return nil, err
+	}
+
+	author, err := s.users.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, co, err := s.codeownerRules(ctx, author.TeamName)
+	if err != nil || co.Mode != domain.CodeownersModeRequire || co.Content == "" {
+		return nil, err
+	}
+
+	reviewers := make([]domain.User, 0, len(pr.AssignedReviewers))
+	for _, id := range pr.AssignedReviewers {
+		u, err := s.users.GetUserByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		reviewers = append(reviewers, u)
+	}
+
+	owned, err := s.ownedFiles(ctx, rules, files, reviewers)
+	if err != nil {
+		return nil, err
+	}
+
+	var req ownerRequirement
+	for _, f := range owned {
+		if len(f.owners) == 1 && f.owners[0].ID == reviewerID {
+			req.files = append(req.files, f.path)
+		}
+	}
+	if len(req.files) == 0 {
+		return nil, nil
+	}
+	req.rules = rules
+	return &req, nil
+}
+
+// requiredOwners keeps the candidates who own every file in r; a nil r keeps
+// them all.
+func (s *PRService) requiredOwners(ctx context.Context, r *ownerRequirement, candidates []domain.User) ([]domain.User, error) {
+	if r == nil || len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	owned, err := s.ownedFiles(ctx, r.rules, r.files, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(slices.Clone(candidates), func(c domain.User) bool {
+		return slices.ContainsFunc(owned, func(f ownedFile) bool { return !containsUser(f.owners, c.ID) })
+	}), nil
+}
+
+func containsUser(users []domain.User, id domain.UserID) bool {
+	return slices.ContainsFunc(users, func(u domain.User) bool { return u.ID == id })
+}
+
+// ownedFiles matches the changed files against the ruleset and resolves the
+// owners of each owned file to the available candidates. Owners are
+// @user_id, @org/team_name or a user's email.
+func (s *PRService) ownedFiles(ctx context.Context, rules codeowners.Ruleset, files []string, available []domain.User) ([]ownedFile, error) {
+	files = slices.Clone(files)
+	slices.Sort(files)
+	files = slices.Compact(files)
+
+	teamMembers := make(map[domain.TeamName][]domain.UserID)
+
+	var owned []ownedFile
+	for _, path := range files {
+		tokens := rules.Owners(path)
+		if len(tokens) == 0 {
+			continue
+		}
+
+		f := ownedFile{path: path}
+		for _, c := range available {
+			ok, err := s.isOwner(ctx, c, tokens, teamMembers)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				f.owners = append(f.owners, c)
+			}
+		}
+		owned = append(owned, f)
+	}
+	return owned, nil
+}
+
+func (s *PRService) isOwner(ctx context.Context, u domain.User, tokens []string, teamMembers map[domain.TeamName][]domain.UserID) (bool, error) {
+	for _, t := range tokens {
+		name, isHandle := strings.CutPrefix(t, "@")
+		switch {
+		case !isHandle:
+			if u.Email != "" && strings.EqualFold(u.Email, t) {
+				return true, nil
+			}
+		case strings.Contains(name, "/"):
+			team := domain.TeamName(name[strings.LastIndex(name, "/")+1:])
+			members, ok := teamMembers[team]
+			if !ok {
+				t, err := s.teams.GetTeamByName(ctx, team)
+				if err != nil && !domain.IsDomainError(err, domain.ErrNotFound) {
+					return false, err
+				}
+				for _, m := range t.Members {
+					members = append(members, m.ID)
+				}
+				teamMembers[team] = members
+			}
+			if slices.Contains(members, u.ID) {
+				return true, nil
+			}
+		default:
+			if domain.UserID(name) == u.ID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
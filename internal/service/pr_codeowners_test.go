@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+const ownersFile = `
+/db/       @u2 @p1
+/deploy/   @p2
+*.md       @u3
+`
+
+func codeownersWorld(mode domain.CodeownersMode) (*memUsers, *memPRs, *memTeams) {
+	users := &memUsers{users: []domain.User{
+		{ID: "u1", TeamName: "backend", IsActive: true},
+		{ID: "u2", TeamName: "backend", IsActive: true},
+		{ID: "u3", TeamName: "backend", IsActive: true},
+		{ID: "u4", TeamName: "backend", IsActive: true},
+		{ID: "p1", TeamName: "platform", IsActive: true},
+		{ID: "p2", TeamName: "platform", IsActive: true},
+	}}
+	prs := &memPRs{prs: map[domain.PullRequestID]domain.PullRequest{}, files: map[domain.PullRequestID][]string{}}
+	teams := &memTeams{
+		settings:   map[domain.TeamName]domain.TeamSettings{"backend": {TeamName: "backend", MinReviewers: 1, MaxReviewers: 3}},
+		fallbacks:  map[domain.TeamName][]domain.TeamName{"backend": {"platform"}},
+		codeowners: map[domain.TeamName]domain.Codeowners{"backend": {TeamName: "backend", Content: ownersFile, Mode: mode}},
+	}
+	return users, prs, teams
+}
+
+func TestSelectReviewersBorrowsCodeOwnersFromFallbacks(t *testing.T) {
+	users, prs, teams := codeownersWorld(domain.CodeownersModeRequire)
+	svc := newTestPRService(users, prs, teams)
+
+	pr := domain.PullRequest{ID: "pr-1", AuthorID: "u1", ChangedFiles: []string{"deploy/app.yaml", "db/schema.sql"}}
+	sel, err := svc.selectReviewers(context.Background(), users.users[0], pr)
+	if err != nil {
+		t.Fatalf("selectReviewers: %v", err)
+	}
+
+	var owners []string
+	for _, o := range sel.report.CodeOwners {
+		owners = append(owners, string(o.ReviewerID)+":"+string(o.FallbackTeam)+":"+strings.Join(o.Paths, ","))
+	}
+	// The home owner u2 wins over p1 for db/, only p2 owns deploy/.
+	if want := []string{"u2::db/schema.sql", "p2:platform:deploy/app.yaml"}; !slices.Equal(owners, want) {
+		t.Errorf("code owners = %v, want %v", owners, want)
+	}
+	if len(sel.report.Fallbacks) != 1 || sel.report.Fallbacks[0].TeamName != "platform" || !slices.Equal(sel.report.Fallbacks[0].Reviewers, []domain.UserID{"p2"}) {
+		t.Errorf("fallbacks = %v, want p2 from platform", sel.report.Fallbacks)
+	}
+}
+
+func TestReassignKeepsRequiredCodeOwner(t *testing.T) {
+	users, prs, teams := codeownersWorld(domain.CodeownersModeRequire)
+	prs.prs["pr-1"] = domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []domain.UserID{"u2", "u3"}}
+	prs.files["pr-1"] = []string{"db/schema.sql", "README.md"}
+	svc := newTestPRService(users, prs, teams)
+
+	// u4 is free in the home team but owns nothing; p1 owns db/.
+	res, err := svc.reassign(context.Background(), ReassignInput{PullRequestID: "pr-1", OldReviewerID: "u2"}, domain.AssignmentReasonReassign)
+	if err != nil {
+		t.Fatalf("reassign: %v", err)
+	}
+	if res.NewReviewerID != "p1" || res.FallbackTeam != "platform" {
+		t.Errorf("replaced by %s from %q, want p1 from platform", res.NewReviewerID, res.FallbackTeam)
+	}
+
+	_, err = svc.reassign(context.Background(), ReassignInput{PullRequestID: "pr-1", OldReviewerID: "u3", NewReviewerID: "u4"}, domain.AssignmentReasonManual)
+	if !domain.IsDomainError(err, domain.ErrNoCandidate) || !strings.Contains(err.Error(), "README.md") {
+		t.Errorf("manual reassign to a non-owner: err = %v, want NO_CANDIDATE naming README.md", err)
+	}
+}
+
+func TestReassignIgnoresOwnershipOutsideRequireMode(t *testing.T) {
+	users, prs, teams := codeownersWorld(domain.CodeownersModePrefer)
+	prs.prs["pr-1"] = domain.PullRequest{ID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []domain.UserID{"u2", "u3"}}
+	prs.files["pr-1"] = []string{"db/schema.sql"}
+	svc := newTestPRService(users, prs, teams)
+
+	res, err := svc.reassign(context.Background(), ReassignInput{PullRequestID: "pr-1", OldReviewerID: "u2"}, domain.AssignmentReasonReassign)
+	if err != nil {
+		t.Fatalf("reassign: %v", err)
+	}
+	if res.NewReviewerID != "u4" {
+		t.Errorf("replaced by %s, want u4 from the home team", res.NewReviewerID)
+	}
+}
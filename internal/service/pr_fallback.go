@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+// FallbackAssignment lists reviewers borrowed from one of the fallback teams.
+type FallbackAssignment struct {
+	TeamName  domain.TeamName
+	Reviewers []domain.UserID
+
+	// members holds the borrowed users in the order of Reviewers, so their
+	// skills and names are at hand without another lookup; loads holds
+	// their open reviews.
+	members []domain.User
+	loads   map[domain.UserID]domain.ReviewerLoad
+}
+
+// fallbackSlots returns how many reviewers a PR may borrow from fallback
+// teams. They only stand in when the home team cannot staff the PR: it has
+// no reviewer at all, or fewer than the team minimum. Borrowing stops at
+// that minimum; the remaining free slots wait in the backlog for the home
+// team instead of going to outsiders.
+func fallbackSlots(settings domain.TeamSettings, have, free int) int {
+	need := settings.MinReviewers - have
+	if have == 0 {
+		need = max(need, 1)
+	}
+	return min(max(need, 0), free)
+}
+
+// fallbackPool holds the members of a fallback team who could take a review
+// right now, within the capacity limits of their own team, and their loads.
+type fallbackPool struct {
+	team      domain.TeamName
+	available []domain.User
+	loads     map[domain.UserID]domain.ReviewerLoad
+}
+
+// fallbackPools returns the pools of the fallback teams of home, in the
+// declared order.
+func (s *PRService) fallbackPools(ctx context.Context, home domain.TeamName, exclude []domain.UserID) ([]fallbackPool, error) {
+	teams, err := s.teams.GetFallbackTeams(ctx, home)
+	if err != nil {
+		s.logger.Error("get fallback teams", slog.String("team", string(home)), slog.Any("err", err))
+		return nil, err
+	}
+
+	pools := make([]fallbackPool, 0, len(teams))
+	for _, team := range teams {
+		candidates, err := s.users.GetActiveTeamMembersExcept(ctx, team, exclude)
+		if err != nil {
+			s.logger.Error("get fallback candidates", slog.String("team", string(team)), slog.Any("err", err))
+			return nil, err
+		}
+
+		settings, err := s.teams.GetSettings(ctx, team)
+		if err != nil {
+			s.logger.Error("get fallback team settings", slog.String("team", string(team)), slog.Any("err", err))
+			return nil, err
+		}
+
+		loads, err := s.reviewerLoads(ctx, team, candidates)
+		if err != nil {
+			return nil, err
+		}
+		available, _ := withinCapacity(settings, candidates, loads)
+		pools = append(pools, fallbackPool{team: team, available: available, loads: loads})
+	}
+	return pools, nil
+}
+
+// pickFromFallbacks borrows up to limit reviewers from the fallback teams of
+// home, taking as many as possible from each team before moving to the next.
+// Fallback members are subject to the capacity limits of their own team.
+func (s *PRService) pickFromFallbacks(ctx context.Context, home domain.TeamName, exclude []domain.UserID, limit int) ([]FallbackAssignment, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	pools, err := s.fallbackPools(ctx, home, exclude)
+	if err != nil {
+		return nil, err
+	}
+	return s.pickFromPools(ctx, pools, limit)
+}
+
+func (s *PRService) pickFromPools(ctx context.Context, pools []fallbackPool, limit int) ([]FallbackAssignment, error) {
+	var result []FallbackAssignment
+	for _, pool := range pools {
+		if limit <= 0 {
+			break
+		}
+
+		picked, err := s.pickReviewers(ctx, pool.team, pool.available, pool.loads, limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(picked) == 0 {
+			continue
+		}
+
+		members := make([]domain.User, 0, len(picked))
+		for _, id := range picked {
+			members = append(members, pool.available[slices.IndexFunc(pool.available, func(u domain.User) bool { return u.ID == id })])
+		}
+
+		result = append(result, FallbackAssignment{TeamName: pool.team, Reviewers: picked, members: members, loads: pool.loads})
+		limit -= len(picked)
+	}
+
+	return result, nil
+}
+
+// addFallback records a reviewer borrowed from their team, keeping one entry
+// per team.
+func addFallback(fallbacks []FallbackAssignment, u domain.User) []FallbackAssignment {
+	for i := range fallbacks {
+		if fallbacks[i].TeamName == u.TeamName {
+			fallbacks[i].Reviewers = append(fallbacks[i].Reviewers, u.ID)
+			fallbacks[i].members = append(fallbacks[i].members, u)
+			return fallbacks
+		}
+	}
+	return append(fallbacks, FallbackAssignment{TeamName: u.TeamName, Reviewers: []domain.UserID{u.ID}, members: []domain.User{u}})
+}
+
+// borrowedUsers returns every member borrowed in fallbacks.
+func borrowedUsers(fallbacks []FallbackAssignment) []domain.User {
+	var result []domain.User
+	for _, f := range fallbacks {
+		result = append(result, f.members...)
+	}
+	return result
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+func TestPickFromFallbacksOrderAndExclusion(t *testing.T) {
+	users := &memUsers{users: []domain.User{
+		{ID: "h1", TeamName: "home", IsActive: true},
+		{ID: "a1", TeamName: "alpha", IsActive: true},
+		{ID: "a2", TeamName: "alpha", IsActive: true},
+		{ID: "b1", TeamName: "beta", IsActive: true},
+		{ID: "b2", TeamName: "beta", IsActive: false},
+		{ID: "b3", TeamName: "beta", IsActive: true, MaxOpenReviews: capacity(1)},
+		{ID: "c1", TeamName: "gamma", IsActive: true},
+	}}
+	prs := &memPRs{prs: map[domain.PullRequestID]domain.PullRequest{
+		"pr-busy": {ID: "pr-busy", AuthorID: "h1", Status: domain.PRStatusOpen, AssignedReviewers: []domain.UserID{"a2", "b3"}},
+	}}
+	teams := &memTeams{fallbacks: map[domain.TeamName][]domain.TeamName{"home": {"alpha", "beta", "gamma"}}}
+	svc := newTestPRService(users, prs, teams)
+
+	tests := []struct {
+		name    string
+		exclude []domain.UserID
+		limit   int
+		want    []FallbackAssignment
+	}{
+		{"no slots", nil, 0, nil},
+		// a2 is busier than a1 under the default least-loaded strategy.
+		{"first team first", nil, 1, []FallbackAssignment{{TeamName: "alpha", Reviewers: []domain.UserID{"a1"}}}},
+		{
+			"moves on when a team runs out",
+			[]domain.UserID{"a1"},
+			3,
+			[]FallbackAssignment{
+				{TeamName: "alpha", Reviewers: []domain.UserID{"a2"}},
+				// b2 is inactive and b3 is at capacity.
+				{TeamName: "beta", Reviewers: []domain.UserID{"b1"}},
+				{TeamName: "gamma", Reviewers: []domain.UserID{"c1"}},
+			},
+		},
+		{
+			"skips excluded teams entirely",
+			[]domain.UserID{"a1", "a2", "b1"},
+			2,
+			[]FallbackAssignment{{TeamName: "gamma", Reviewers: []domain.UserID{"c1"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := svc.pickFromFallbacks(context.Background(), "home", tt.exclude, tt.limit)
+		if err != nil {
+			t.Fatalf("%s: pickFromFallbacks: %v", tt.name, err)
+		}
+		if !slices.EqualFunc(got, tt.want, func(a, b FallbackAssignment) bool {
+			return a.TeamName == b.TeamName && slices.Equal(a.Reviewers, b.Reviewers)
+		}) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFallbackSlots(t *testing.T) {
+	settings := domain.TeamSettings{MinReviewers: 2, MaxReviewers: 3}
+
+	tests := []struct {
+		name       string
+		min        int
+		have, free int
+		want       int
+	}{
+		{"home team offered nobody", 2, 0, 3, 2},
+		{"home team below minimum", 2, 1, 2, 1},
+		{"home team reached minimum", 2, 2, 1, 0},
+		{"no minimum, nobody yet", 0, 0, 2, 1},
+		{"no minimum, home has one", 0, 1, 1, 0},
+		{"no free slots", 2, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		settings.MinReviewers = tt.min
+		if got := fallbackSlots(settings, tt.have, tt.free); got != tt.want {
+			t.Errorf("%s: fallbackSlots = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSelectReviewersBorrowsOnlyBelowMinimum(t *testing.T) {
+	users := &memUsers{users: []domain.User{
+		{ID: "u1", TeamName: "backend", IsActive: true},
+		{ID: "u2", TeamName: "backend", IsActive: true},
+		{ID: "p1", TeamName: "platform", IsActive: true},
+		{ID: "p2", TeamName: "platform", IsActive: true},
+	}}
+	prs := &memPRs{prs: map[domain.PullRequestID]domain.PullRequest{}}
+	teams := &memTeams{
+		settings:  map[domain.TeamName]domain.TeamSettings{"backend": {TeamName: "backend", MinReviewers: 1, MaxReviewers: 3}},
+		fallbacks: map[domain.TeamName][]domain.TeamName{"backend": {"platform"}},
+	}
+	svc := newTestPRService(users, prs, teams)
+
+	sel, err := svc.selectReviewers(context.Background(), users.users[0], domain.PullRequest{ID: "pr-1", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("selectReviewers: %v", err)
+	}
+	if want := []domain.UserID{"u2"}; !slices.Equal(sel.report.Reviewers, want) {
+		t.Errorf("reviewers = %v, want %v", sel.report.Reviewers, want)
+	}
+	if len(sel.report.Fallbacks) != 0 {
+		t.Errorf("fallbacks = %v, want none once the home team met the minimum", sel.report.Fallbacks)
+	}
+}
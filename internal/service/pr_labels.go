@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+// LabelMatch explains how the PR labels were matched against reviewer
+// skills. Matched lists the assigned reviewers whose skills match.
+type LabelMatch struct {
+	Labels    []string
+	Matched   []SkillMatch
+	Rationale string
+}
+
+type SkillMatch struct {
+	ReviewerID domain.UserID
+	Skills     []string
+}
+
+// pickSkillMatch tries to make sure at least one reviewer has a skill that
+// matches the PR labels. assigned are the reviewers picked so far; a
+// matching reviewer, if picked, takes one of the slots left.
+func (s *PRService) pickSkillMatch(ctx context.Context, team domain.TeamName, labels []string, available []domain.User, loads map[domain.UserID]domain.ReviewerLoad, assigned []domain.UserID, slots int) ([]domain.UserID, *LabelMatch, error) {
+	if len(labels) == 0 {
+		return nil, nil, nil
+	}
+
+	match := &LabelMatch{Labels: labels}
+
+	var candidates []domain.User
+	for _, u := range available {
+		if len(u.MatchingSkills(labels)) == 0 {
+			continue
+		}
+		if slices.Contains(assigned, u.ID) {
+			match.Rationale = "already assigned reviewer " + string(u.ID) + " has matching skills"
+			return nil, match, nil
+		}
+		candidates = append(candidates, u)
+	}
+
+	switch {
+	case len(candidates) == 0:
+		match.Rationale = "no available team member has skills matching the labels"
+		return nil, match, nil
+	case slots <= 0:
+		match.Rationale = "no reviewer slot left for a member with matching skills"
+		return nil, match, nil
+	}
+
+	chosen, err := s.pickReviewers(ctx, team, candidates, loads, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	match.Rationale = string(chosen[0]) + " was picked for skills matching the labels"
+	return chosen, match, nil
+}
+
+// skillMatches lists the assigned reviewers with skills matching labels.
+// users must hold every assigned reviewer, borrowed ones included.
+func skillMatches(labels []string, users []domain.User, assigned []domain.UserID) []SkillMatch {
+	var result []SkillMatch
+	for _, id := range assigned {
+		i := slices.IndexFunc(users, func(u domain.User) bool { return u.ID == id })
+		if i < 0 {
+			continue
+		}
+		if skills := users[i].MatchingSkills(labels); len(skills) > 0 {
+			result = append(result, SkillMatch{ReviewerID: id, Skills: skills})
+		}
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+func TestPickSkillMatch(t *testing.T) {
+	available := []domain.User{
+		{ID: "u2", TeamName: "backend", IsActive: true, Skills: []string{"go"}},
+		{ID: "u3", TeamName: "backend", IsActive: true, Skills: []string{"db", "go"}},
+		{ID: "u4", TeamName: "backend", IsActive: true, Skills: []string{"db"}},
+	}
+	users := &memUsers{users: available}
+	// u3 is busier than u4 under the default least-loaded strategy.
+	prs := &memPRs{prs: map[domain.PullRequestID]domain.PullRequest{
+		"pr-busy": {ID: "pr-busy", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []domain.UserID{"u3"}},
+	}}
+	svc := newTestPRService(users, prs, &memTeams{})
+	loads, err := svc.reviewerLoads(context.Background(), "backend", available)
+	if err != nil {
+		t.Fatalf("reviewerLoads: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		labels    []string
+		assigned  []domain.UserID
+		slots     int
+		want      []domain.UserID
+		rationale string
+	}{
+		{"no labels", nil, nil, 2, nil, ""},
+		{"picks the least loaded match", []string{"db"}, nil, 2, []domain.UserID{"u4"}, "u4 was picked for skills matching the labels"},
+		{"assigned reviewer already matches", []string{"db"}, []domain.UserID{"u3"}, 1, nil, "already assigned reviewer u3 has matching skills"},
+		{"nobody matches", []string{"frontend"}, nil, 2, nil, "no available team member has skills matching the labels"},
+		{"no slot left", []string{"db"}, []domain.UserID{"u2"}, 0, nil, "no reviewer slot left for a member with matching skills"},
+	}
+
+	for _, tt := range tests {
+		got, match, err := svc.pickSkillMatch(context.Background(), "backend", tt.labels, available, loads, tt.assigned, tt.slots)
+		if err != nil {
+			t.Fatalf("%s: pickSkillMatch: %v", tt.name, err)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: picked %v, want %v", tt.name, got, tt.want)
+		}
+		switch {
+		case tt.rationale == "" && match != nil:
+			t.Errorf("%s: match = %+v, want nil", tt.name, match)
+		case tt.rationale != "" && (match == nil || match.Rationale != tt.rationale):
+			t.Errorf("%s: match = %+v, want rationale %q", tt.name, match, tt.rationale)
+		}
+	}
+}
+
+func TestSelectReviewersMatchesBorrowedSkills(t *testing.T) {
+	users := &memUsers{users: []domain.User{
+		{ID: "u1", TeamName: "backend", IsActive: true},
+		{ID: "p1", TeamName: "platform", IsActive: true, Skills: []string{"db"}},
+	}}
+	prs := &memPRs{prs: map[domain.PullRequestID]domain.PullRequest{}}
+	teams := &memTeams{
+		settings:  map[domain.TeamName]domain.TeamSettings{"backend": {TeamName: "backend", MinReviewers: 1, MaxReviewers: 2}},
+		fallbacks: map[domain.TeamName][]domain.TeamName{"backend": {"platform"}},
+	}
+	svc := newTestPRService(users, prs, teams)
+
+	pr := domain.PullRequest{ID: "pr-1", AuthorID: "u1", Labels: []string{"db"}}
+	sel, err := svc.selectReviewers(context.Background(), users.users[0], pr)
+	if err != nil {
+		t.Fatalf("selectReviewers: %v", err)
+	}
+
+	if !slices.Equal(sel.report.Reviewers, []domain.UserID{"p1"}) {
+		t.Fatalf("reviewers = %v, want [p1]", sel.report.Reviewers)
+	}
+	m := sel.report.LabelMatch
+	if m == nil || len(m.Matched) != 1 || m.Matched[0].ReviewerID != "p1" || !slices.Equal(m.Matched[0].Skills, []string{"db"}) {
+		t.Errorf("label match = %+v, want p1 matched on db", m)
+	}
+}
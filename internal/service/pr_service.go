@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/freeholder/pr-reviewer-service/internal/domain"
@@ -12,11 +15,12 @@ import (
 )
 
 type PRService struct {
-	logger *slog.Logger
-	users  UserRepository
-	teams  TeamRepository
-	prs    PullRequestRepository
-	rand   random.Randomizer
+	logger  *slog.Logger
+	users   UserRepository
+	teams   TeamRepository
+	prs     PullRequestRepository
+	backlog BacklogRepository
+	rand    random.Randomizer
 }
 
 type BulkNotReassignedPR struct {
@@ -25,75 +29,326 @@ type BulkNotReassignedPR struct {
 	Reason        string
 }
 
+// BulkFallbackReassignment is a review that went to a member of a fallback
+// team because the home team had no replacement.
+type BulkFallbackReassignment struct {
+	PullRequestID domain.PullRequestID
+	OldUserID     domain.UserID
+	NewUserID     domain.UserID
+	TeamName      domain.TeamName
+}
+
 type BulkDeactivateResult struct {
 	TeamName           domain.TeamName
 	DeactivatedUserIDs []domain.UserID
 	ReassignedCount    int
 	NotReassigned      []BulkNotReassignedPR
+	Fallbacks          []BulkFallbackReassignment
+}
+
+type ReassignInput struct {
+	PullRequestID domain.PullRequestID
+	OldReviewerID domain.UserID
+	NewReviewerID domain.UserID
+	Actor         domain.UserID
+}
+
+// ReassignResult describes a replaced reviewer. FallbackTeam is set when the
+// home team had no candidate and the new reviewer was borrowed.
+type ReassignResult struct {
+	PR            domain.PullRequest
+	NewReviewerID domain.UserID
+	FallbackTeam  domain.TeamName
+}
+
+// AssignmentReport explains how the reviewer slots of a new PR were filled.
+// AtCapacity lists candidates skipped because they already hold as many
+// open reviews as they may. CodeOwners and Fallbacks list the parts of
+// Reviewers picked as owners of the changed files and borrowed from fallback
+// teams. LabelMatch is set when the PR has labels.
+type AssignmentReport struct {
+	Requested  int
+	Reviewers  []domain.UserID
+	AtCapacity []domain.UserID
+	CodeOwners []CodeOwnerAssignment
+	Fallbacks  []FallbackAssignment
+	LabelMatch *LabelMatch
+}
+
+func (r AssignmentReport) Unfilled() int {
+	return max(r.Requested-len(r.Reviewers), 0)
 }
 
-func NewPRService(logger *slog.Logger, users UserRepository, prs PullRequestRepository, rand random.Randomizer, teams TeamRepository) *PRService {
+type CreatePRInput struct {
+	ID           domain.PullRequestID
+	Name         string
+	AuthorID     domain.UserID
+	Draft        bool
+	ChangedFiles []string
+	Labels       []string
+}
+
+func NewPRService(logger *slog.Logger, users UserRepository, prs PullRequestRepository, rand random.Randomizer, teams TeamRepository, backlog BacklogRepository) *PRService {
 	return &PRService{
-		logger: logger,
-		users:  users,
-		prs:    prs,
-		rand:   rand,
-		teams:  teams,
+		logger:  logger,
+		users:   users,
+		prs:     prs,
+		rand:    rand,
+		teams:   teams,
+		backlog: backlog,
 	}
 }
-func (s *PRService) Create(ctx context.Context, id domain.PullRequestID, name string, authorID domain.UserID) (domain.PullRequest, error) {
-	if id == "" {
-		return domain.PullRequest{}, domain.NewValidationError("pull_request_id", "must not be empty")
+
+// Create stores a new PR and, unless it is a draft, assigns its reviewers.
+// Reviewer slots that could not be filled are reported, not treated as errors.
+func (s *PRService) Create(ctx context.Context, in CreatePRInput) (domain.PullRequest, AssignmentReport, error) {
+	if in.ID == "" {
+		return domain.PullRequest{}, AssignmentReport{}, domain.NewValidationError("pull_request_id", "must not be empty")
 	}
-	if name == "" {
-		return domain.PullRequest{}, domain.NewValidationError("pull_request_name", "must not be empty")
+	if in.Name == "" {
+		return domain.PullRequest{}, AssignmentReport{}, domain.NewValidationError("pull_request_name", "must not be empty")
 	}
-	if authorID == "" {
-		return domain.PullRequest{}, domain.NewValidationError("author_id", "must not be empty")
+	if in.AuthorID == "" {
+		return domain.PullRequest{}, AssignmentReport{}, domain.NewValidationError("author_id", "must not be empty")
 	}
 
-	author, err := s.users.GetUserByID(ctx, authorID)
+	author, err := s.users.GetUserByID(ctx, in.AuthorID)
 	if err != nil {
-		s.logger.Error("get author for pr", slog.String("author_id", string(authorID)), slog.Any("err", err))
-		return domain.PullRequest{}, err
+		s.logger.Error("get author for pr", slog.String("author_id", string(in.AuthorID)), slog.Any("err", err))
+		return domain.PullRequest{}, AssignmentReport{}, err
 	}
 
-	candidates, err := s.users.GetActiveTeamMembersExcept(ctx, author.TeamName, []domain.UserID{author.ID})
-	if err != nil {
-		s.logger.Error("get candidates for pr reviewers", slog.String("team", string(author.TeamName)), slog.Any("err", err))
-		return domain.PullRequest{}, err
+	pr := domain.PullRequest{
+		ID:           in.ID,
+		Name:         in.Name,
+		AuthorID:     in.AuthorID,
+		Status:       domain.PRStatusDraft,
+		ChangedFiles: in.ChangedFiles,
+		Labels:       domain.NormalizeTags(in.Labels),
 	}
 
-	assigned := s.pickReviewers(candidates, 2)
+	var report AssignmentReport
 
-	pr := domain.PullRequest{
-		ID:                id,
-		Name:              name,
-		AuthorID:          authorID,
-		Status:            domain.PRStatusOpen,
-		AssignedReviewers: assigned,
+	if !in.Draft {
+		var settings domain.TeamSettings
+
+		report, settings, err = s.selectInitialReviewers(ctx, author, pr)
+		if err != nil {
+			return domain.PullRequest{}, AssignmentReport{}, err
+		}
+
+		pr.Status = domain.PRStatusOpen
+		pr.AssignedReviewers = report.Reviewers
+		pr.Reviews = domain.PendingReviews(report.Reviewers)
+
+		if err := pr.ValidateReviewerCount(settings, len(report.AtCapacity)); err != nil {
+			return domain.PullRequest{}, AssignmentReport{}, err
+		}
 	}
 
 	if err := pr.Validate(); err != nil {
-		return domain.PullRequest{}, err
+		return domain.PullRequest{}, AssignmentReport{}, err
 	}
 
 	if err := s.prs.Create(ctx, pr); err != nil {
-		s.logger.Error("create pr", slog.String("pr_id", string(id)), slog.String("author_id", string(authorID)), slog.Any("err", err))
-		return domain.PullRequest{}, err
+		s.logger.Error("create pr", slog.String("pr_id", string(in.ID)), slog.String("author_id", string(in.AuthorID)), slog.Any("err", err))
+		return domain.PullRequest{}, AssignmentReport{}, err
 	}
 
-	return pr, nil
+	s.enqueueUnfilled(ctx, pr.ID, report)
+
+	return pr, report, nil
+}
+
+// selection is the outcome of the reviewer pipeline for a PR. candidates are
+// the team members that may review it today, including those at capacity.
+// loads holds the open reviews of the candidates and borrowed reviewers.
+type selection struct {
+	report     AssignmentReport
+	settings   domain.TeamSettings
+	candidates []domain.User
+	loads      map[domain.UserID]domain.ReviewerLoad
+}
+
+// shortage returns NO_CANDIDATE when the PR cannot get the team minimum of
+// reviewers. Members at capacity count, as their slots wait in the backlog.
+func (sel selection) shortage(assigned int) error {
+	found := assigned + len(sel.report.Reviewers) + len(sel.report.AtCapacity)
+	if found < sel.settings.MinReviewers {
+		return domain.NewDomainError(domain.ErrNoCandidate, fmt.Sprintf("team requires at least %d reviewers, only %d active candidates", sel.settings.MinReviewers, found))
+	}
+	return nil
+}
+
+func (s *PRService) selectInitialReviewers(ctx context.Context, author domain.User, pr domain.PullRequest) (AssignmentReport, domain.TeamSettings, error) {
+	sel, err := s.selectReviewers(ctx, author, pr)
+	if err != nil {
+		return AssignmentReport{}, domain.TeamSettings{}, err
+	}
+	if err := sel.shortage(len(pr.AssignedReviewers)); err != nil {
+		return AssignmentReport{}, domain.TeamSettings{}, err
+	}
+	return sel.report, sel.settings, nil
+}
+
+// selectReviewers fills the free reviewer slots of pr: owners of the changed
+// files first, then a member whose skills match the labels, then the team
+// strategy and finally the fallback teams. Nothing is stored. When a later
+// step fails, the returned selection still holds the candidates and those at
+// capacity, so a dry run can explain them.
+func (s *PRService) selectReviewers(ctx context.Context, author domain.User, pr domain.PullRequest) (selection, error) {
+	exclude := append([]domain.UserID{author.ID}, pr.AssignedReviewers...)
+
+	candidates, err := s.users.GetActiveTeamMembersExcept(ctx, author.TeamName, exclude)
+	if err != nil {
+		s.logger.Error("get candidates for pr reviewers", slog.String("team", string(author.TeamName)), slog.Any("err", err))
+		return selection{}, err
+	}
+
+	settings, err := s.teams.GetSettings(ctx, author.TeamName)
+	if err != nil {
+		s.logger.Error("get team settings for pr", slog.String("team", string(author.TeamName)), slog.Any("err", err))
+		return selection{}, err
+	}
+
+	slots := max(settings.MaxReviewers-len(pr.AssignedReviewers), 0)
+
+	loads, err := s.reviewerLoads(ctx, author.TeamName, candidates)
+	if err != nil {
+		return selection{}, err
+	}
+	available, full := withinCapacity(settings, candidates, loads)
+
+	sel := selection{
+		report:     AssignmentReport{Requested: slots, AtCapacity: full},
+		settings:   settings,
+		candidates: candidates,
+		loads:      loads,
+	}
+
+	owners, err := s.pickCodeOwners(ctx, author.TeamName, pr.ChangedFiles, available, loads, exclude, slots)
+	if err != nil {
+		return sel, err
+	}
+
+	var (
+		assigned  []domain.UserID
+		fallbacks []FallbackAssignment
+	)
+	for _, o := range owners {
+		assigned = append(assigned, o.ReviewerID)
+		if o.FallbackTeam != "" {
+			fallbacks = addFallback(fallbacks, o.owner)
+		}
+	}
+
+	// Borrowed owners are all assigned already, so they only count as a
+	// match, never as a new pick.
+	skilled, labelMatch, err := s.pickSkillMatch(ctx, author.TeamName, pr.Labels, append(slices.Clone(available), borrowedUsers(fallbacks)...), loads, assigned, slots-len(assigned))
+	if err != nil {
+		return sel, err
+	}
+	assigned = append(assigned, skilled...)
+	rest := slices.DeleteFunc(slices.Clone(available), func(u domain.User) bool { return slices.Contains(assigned, u.ID) })
+
+	more, err := s.pickReviewers(ctx, author.TeamName, rest, loads, slots-len(assigned))
+	if err != nil {
+		return sel, err
+	}
+	assigned = append(assigned, more...)
+
+	borrowed, err := s.pickFromFallbacks(ctx, author.TeamName, append(exclude, assigned...), fallbackSlots(settings, len(pr.AssignedReviewers)+len(assigned), slots-len(assigned)))
+	if err != nil {
+		return sel, err
+	}
+	for _, f := range borrowed {
+		maps.Copy(loads, f.loads)
+		for _, u := range f.members {
+			assigned = append(assigned, u.ID)
+			fallbacks = addFallback(fallbacks, u)
+		}
+	}
+
+	if labelMatch != nil {
+		labelMatch.Matched = skillMatches(pr.Labels, append(slices.Clone(available), borrowedUsers(fallbacks)...), assigned)
+	}
+
+	sel.report.Reviewers = assigned
+	sel.report.CodeOwners = owners
+	sel.report.Fallbacks = fallbacks
+	sel.report.LabelMatch = labelMatch
+	return sel, nil
+}
+
+// reviewerLoads fetches the open review loads of users in one query, so the
+// capacity check and the team strategy work from the same numbers.
+func (s *PRService) reviewerLoads(ctx context.Context, team domain.TeamName, users []domain.User) (map[domain.UserID]domain.ReviewerLoad, error) {
+	if len(users) == 0 {
+		return make(map[domain.UserID]domain.ReviewerLoad), nil
+	}
+
+	ids := make([]domain.UserID, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+
+	loads, err := s.prs.GetReviewerLoads(ctx, ids)
+	if err != nil {
+		s.logger.Error("get reviewer loads", slog.String("team", string(team)), slog.Any("err", err))
+		return nil, err
+	}
+	return loads, nil
+}
+
+// withinCapacity splits candidates into those who can take another review
+// and those already at their open review limit.
+func withinCapacity(settings domain.TeamSettings, candidates []domain.User, loads map[domain.UserID]domain.ReviewerLoad) ([]domain.User, []domain.UserID) {
+	var (
+		available []domain.User
+		full      []domain.UserID
+	)
+	for _, c := range candidates {
+		limit := domain.ReviewCapacity(c, settings)
+		if limit > 0 && loads[c.ID].OpenReviews >= limit {
+			full = append(full, c.ID)
+			continue
+		}
+		available = append(available, c)
+	}
+
+	return available, full
 }
 
-func (s *PRService) Merge(ctx context.Context, id domain.PullRequestID) (domain.PullRequest, error) {
+func (s *PRService) Merge(ctx context.Context, id domain.PullRequestID, actor domain.UserID) (domain.PullRequest, error) {
 	if id == "" {
 		return domain.PullRequest{}, domain.NewValidationError("pull_request_id", "must not be empty")
 	}
 
+	current, err := s.prs.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("get pr before merge", slog.String("pr_id", string(id)), slog.Any("err", err))
+		return domain.PullRequest{}, err
+	}
+
+	switch current.Status {
+	case domain.PRStatusMerged:
+		return current, nil
+	case domain.PRStatusClosed:
+		return domain.PullRequest{}, domain.NewDomainError(domain.ErrPRClosed, "cannot merge closed PR")
+	case domain.PRStatusDraft:
+		return domain.PullRequest{}, domain.NewDomainError(domain.ErrPRDraft, "cannot merge draft PR")
+	}
+
+	settings, err := s.mergePolicy(ctx, current)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	// The policy is checked by SetMerged under a lock on the PR, against the
+	// reviews as they are at the moment of the merge.
 	now := time.Now().UTC()
 
-	pr, err := s.prs.SetMerged(ctx, id, now)
+	pr, err := s.prs.SetMerged(ctx, id, now, actor, "", &settings)
 	if err != nil {
 		s.logger.Error("merge pr", slog.String("pr_id", string(id)), slog.Any("err", err))
 		return domain.PullRequest{}, err
@@ -102,22 +357,89 @@ func (s *PRService) Merge(ctx context.Context, id domain.PullRequestID) (domain.
 	return pr, nil
 }
 
-func (s *PRService) ReassignReviewer(ctx context.Context, prID domain.PullRequestID, oldReviewerID domain.UserID) (domain.PullRequest, domain.UserID, error) {
+// mergePolicy returns the settings of the author's team, which hold the
+// merge policy of pr.
+func (s *PRService) mergePolicy(ctx context.Context, pr domain.PullRequest) (domain.TeamSettings, error) {
+	author, err := s.users.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		s.logger.Error("get author before merge", slog.String("pr_id", string(pr.ID)), slog.String("author_id", string(pr.AuthorID)), slog.Any("err", err))
+		return domain.TeamSettings{}, err
+	}
+
+	settings, err := s.teams.GetSettings(ctx, author.TeamName)
+	if err != nil {
+		s.logger.Error("get team settings before merge", slog.String("team", string(author.TeamName)), slog.Any("err", err))
+		return domain.TeamSettings{}, err
+	}
+
+	return settings, nil
+}
+
+// mergeBlockers checks pr against the merge policy of its author's team.
+func (s *PRService) mergeBlockers(ctx context.Context, pr domain.PullRequest) ([]domain.MergeBlocker, error) {
+	settings, err := s.mergePolicy(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	return settings.MergeBlockers(pr), nil
+}
+
+func (s *PRService) SubmitReview(ctx context.Context, prID domain.PullRequestID, reviewerID domain.UserID, state domain.ReviewState) (domain.PullRequest, error) {
+	if prID == "" {
+		return domain.PullRequest{}, domain.NewValidationError("pull_request_id", "must not be empty")
+	}
+	if reviewerID == "" {
+		return domain.PullRequest{}, domain.NewValidationError("user_id", "must not be empty")
+	}
+	if state != domain.ReviewStateApproved && state != domain.ReviewStateChangesRequested {
+		return domain.PullRequest{}, domain.NewValidationError("state", "must be APPROVED or CHANGES_REQUESTED")
+	}
+
+	pr, err := s.prs.GetByID(ctx, prID)
+	if err != nil {
+		s.logger.Error("get pr before review", slog.String("pr_id", string(prID)), slog.Any("err", err))
+		return domain.PullRequest{}, err
+	}
+
+	if err := requireOpen(pr, "review"); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	updated, err := s.prs.SetReviewState(ctx, prID, reviewerID, state, time.Now().UTC())
+	if err != nil {
+		s.logger.Error("set review state", slog.String("pr_id", string(prID)), slog.String("reviewer_id", string(reviewerID)), slog.String("state", string(state)), slog.Any("err", err))
+		return domain.PullRequest{}, err
+	}
+
+	return updated, nil
+}
+
+func (s *PRService) ReassignReviewer(ctx context.Context, in ReassignInput) (ReassignResult, error) {
+	reason := domain.AssignmentReasonReassign
+	if in.NewReviewerID != "" {
+		reason = domain.AssignmentReasonManual
+	}
+	return s.reassign(ctx, in, reason)
+}
+
+func (s *PRService) reassign(ctx context.Context, in ReassignInput, reason domain.AssignmentReason) (ReassignResult, error) {
+	prID, oldReviewerID := in.PullRequestID, in.OldReviewerID
+
 	if prID == "" {
-		return domain.PullRequest{}, "", domain.NewValidationError("pull_request_id", "must not be empty")
+		return ReassignResult{}, domain.NewValidationError("pull_request_id", "must not be empty")
 	}
 	if oldReviewerID == "" {
-		return domain.PullRequest{}, "", domain.NewValidationError("old_user_id", "must not be empty")
+		return ReassignResult{}, domain.NewValidationError("old_user_id", "must not be empty")
 	}
 
 	pr, err := s.prs.GetByID(ctx, prID)
 	if err != nil {
 		s.logger.Error("get pr before reassign", slog.String("pr_id", string(prID)), slog.Any("err", err))
-		return domain.PullRequest{}, "", err
+		return ReassignResult{}, err
 	}
 
-	if pr.Status == domain.PRStatusMerged {
-		return domain.PullRequest{}, "", domain.NewDomainError(domain.ErrPRMerged, "cannot reassign on merged PR")
+	if err := requireOpen(pr, "reassign on"); err != nil {
+		return ReassignResult{}, err
 	}
 
 	found := false
@@ -128,13 +450,13 @@ func (s *PRService) ReassignReviewer(ctx context.Context, prID domain.PullReques
 		}
 	}
 	if !found {
-		return domain.PullRequest{}, "", domain.NewDomainError(domain.ErrNotAssigned, "reviewer is not assigned to this PR")
+		return ReassignResult{}, domain.NewDomainError(domain.ErrNotAssigned, "reviewer is not assigned to this PR")
 	}
 
 	oldReviewer, err := s.users.GetUserByID(ctx, oldReviewerID)
 	if err != nil {
 		s.logger.Error("get old reviewer for reassign", slog.String("user_id", string(oldReviewerID)), slog.Any("err", err))
-		return domain.PullRequest{}, "", err
+		return ReassignResult{}, err
 	}
 
 	exclude := map[domain.UserID]struct{}{
@@ -153,59 +475,173 @@ func (s *PRService) ReassignReviewer(ctx context.Context, prID domain.PullReques
 	candidates, err := s.users.GetActiveTeamMembersExcept(ctx, oldReviewer.TeamName, excludeIDs)
 	if err != nil {
 		s.logger.Error("get candidates for reassign", slog.String("team", string(oldReviewer.TeamName)), slog.Any("err", err))
-		return domain.PullRequest{}, "", err
+		return ReassignResult{}, err
 	}
 
-	if len(candidates) == 0 {
-		return domain.PullRequest{}, "", domain.NewDomainError(domain.ErrNoCandidate, "no active replacement candidate in team")
+	settings, err := s.teams.GetSettings(ctx, oldReviewer.TeamName)
+	if err != nil {
+		s.logger.Error("get team settings for reassign", slog.String("team", string(oldReviewer.TeamName)), slog.Any("err", err))
+		return ReassignResult{}, err
 	}
 
-	chosenIdxs := s.pickReviewers(candidates, 1)
-	newReviewerID := chosenIdxs[0]
+	loads, err := s.reviewerLoads(ctx, oldReviewer.TeamName, candidates)
+	if err != nil {
+		return ReassignResult{}, err
+	}
+	available, full := withinCapacity(settings, candidates, loads)
 
-	updatedPR, err := s.prs.ReplaceReviewer(ctx, prID, oldReviewerID, newReviewerID)
+	// Under a REQUIRE CODEOWNERS the replacement has to own the files only
+	// the old reviewer covered.
+	required, err := s.requiredOwnership(ctx, pr, oldReviewerID)
+	if err != nil {
+		return ReassignResult{}, err
+	}
+	owners, err := s.requiredOwners(ctx, required, available)
+	if err != nil {
+		return ReassignResult{}, err
+	}
+	notOwner := len(owners) < len(available)
+	available = owners
+
+	var (
+		newReviewerID domain.UserID
+		fallbackTeam  domain.TeamName
+	)
+
+	if in.NewReviewerID != "" {
+		if len(candidates) == 0 {
+			return ReassignResult{}, domain.NewDomainError(domain.ErrNoCandidate, "no active replacement candidate in team")
+		}
+		for _, c := range available {
+			if c.ID == in.NewReviewerID {
+				newReviewerID = c.ID
+				break
+			}
+		}
+		if newReviewerID == "" && slices.Contains(full, in.NewReviewerID) {
+			return ReassignResult{}, domain.NewDomainError(domain.ErrNoCandidate, "requested reviewer is at review capacity")
+		}
+		if newReviewerID == "" && notOwner && slices.ContainsFunc(candidates, func(u domain.User) bool { return u.ID == in.NewReviewerID }) {
+			return ReassignResult{}, domain.NewDomainError(domain.ErrNoCandidate, "requested reviewer is not a code owner of "+strings.Join(required.files, ", "))
+		}
+		if newReviewerID == "" {
+			return ReassignResult{}, domain.NewDomainError(domain.ErrNoCandidate, "requested reviewer is not an active replacement candidate in team")
+		}
+	} else if len(available) > 0 {
+		chosen, err := s.pickReviewers(ctx, oldReviewer.TeamName, available, loads, 1)
+		if err != nil {
+			return ReassignResult{}, err
+		}
+		newReviewerID = chosen[0]
+	} else {
+		pools, err := s.fallbackPools(ctx, oldReviewer.TeamName, excludeIDs)
+		if err != nil {
+			return ReassignResult{}, err
+		}
+		for i := range pools {
+			if pools[i].available, err = s.requiredOwners(ctx, required, pools[i].available); err != nil {
+				return ReassignResult{}, err
+			}
+		}
+		fallbacks, err := s.pickFromPools(ctx, pools, 1)
+		if err != nil {
+			return ReassignResult{}, err
+		}
+		if len(fallbacks) == 0 {
+			if required != nil {
+				return ReassignResult{}, domain.NewDomainError(domain.ErrNoCandidate, "no available code owner for "+strings.Join(required.files, ", "))
+			}
+			if len(candidates) == 0 {
+				return ReassignResult{}, domain.NewDomainError(domain.ErrNoCandidate, "no active replacement candidate in team")
+			}
+			return ReassignResult{}, domain.NewDomainError(domain.ErrNoCandidate, "all replacement candidates in team are at review capacity")
+		}
+		newReviewerID = fallbacks[0].Reviewers[0]
+		fallbackTeam = fallbacks[0].TeamName
+	}
+
+	updatedPR, err := s.prs.ReplaceReviewer(ctx, prID, oldReviewerID, newReviewerID, reason, in.Actor)
 	if err != nil {
 		s.logger.Error("replace reviewer", slog.String("pr_id", string(prID)), slog.String("old_reviewer_id", string(oldReviewerID)), slog.String("new_reviewer_id", string(newReviewerID)), slog.Any("err", err))
-		return domain.PullRequest{}, "", err
+		return ReassignResult{}, err
 	}
 
-	return updatedPR, newReviewerID, nil
+	return ReassignResult{PR: updatedPR, NewReviewerID: newReviewerID, FallbackTeam: fallbackTeam}, nil
 }
 
-func (s *PRService) pickReviewers(candidates []domain.User, limit int) []domain.UserID {
-	n := len(candidates)
-	if n == 0 || limit <= 0 {
-		return nil
+func (s *PRService) ListAssignments(ctx context.Context, prID domain.PullRequestID) ([]domain.ReviewAssignment, error) {
+	if prID == "" {
+		return nil, domain.NewValidationError("pull_request_id", "must not be empty")
 	}
 
-	if n <= limit {
-		ids := make([]domain.UserID, 0, n)
-		for _, u := range candidates {
-			ids = append(ids, u.ID)
-		}
-		return ids
+	if _, err := s.prs.GetByID(ctx, prID); err != nil {
+		s.logger.Error("get pr before list assignments", slog.String("pr_id", string(prID)), slog.Any("err", err))
+		return nil, err
 	}
 
-	used := make(map[int]struct{}, limit)
-	var result []domain.UserID
+	assignments, err := s.prs.ListAssignments(ctx, prID)
+	if err != nil {
+		s.logger.Error("list assignments", slog.String("pr_id", string(prID)), slog.Any("err", err))
+		return nil, err
+	}
 
-	for len(result) < limit {
-		i := s.rand.Intn(n)
-		if _, exists := used[i]; exists {
-			continue
+	return assignments, nil
+}
+
+func (s *PRService) History(ctx context.Context, prID domain.PullRequestID) ([]domain.PREvent, error) {
+	if prID == "" {
+		return nil, domain.NewValidationError("pull_request_id", "must not be empty")
+	}
+
+	if _, err := s.prs.GetByID(ctx, prID); err != nil {
+		s.logger.Error("get pr before history", slog.String("pr_id", string(prID)), slog.Any("err", err))
+		return nil, err
+	}
+
+	events, err := s.prs.ListEvents(ctx, prID)
+	if err != nil {
+		s.logger.Error("list pr events", slog.String("pr_id", string(prID)), slog.Any("err", err))
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// pickReviewers chooses up to limit candidates with the team strategy. loads
+// must hold the candidates' open reviews.
+func (s *PRService) pickReviewers(ctx context.Context, teamName domain.TeamName, candidates []domain.User, loads map[domain.UserID]domain.ReviewerLoad, limit int) ([]domain.UserID, error) {
+	if len(candidates) == 0 || limit <= 0 {
+		return nil, nil
+	}
+
+	pool := make([]ReviewerCandidate, 0, len(candidates))
+	for _, u := range candidates {
+		load, ok := loads[u.ID]
+		if !ok {
+			load = domain.ReviewerLoad{UserID: u.ID}
 		}
-		used[i] = struct{}{}
-		result = append(result, candidates[i].ID)
+		pool = append(pool, ReviewerCandidate{User: u, Load: load})
 	}
 
-	return result
+	if len(pool) <= limit {
+		return candidateIDs(pool), nil
+	}
+
+	strategy, err := s.teams.GetReviewerStrategy(ctx, teamName)
+	if err != nil {
+		s.logger.Error("get team reviewer strategy", slog.String("team", string(teamName)), slog.Any("err", err))
+		return nil, err
+	}
+
+	return NewReviewerSelector(strategy, s.rand).Select(pool, limit), nil
 }
 
-func (s *PRService) BulkDeactivateAndReassign(ctx context.Context, teamName domain.TeamName, userIDs []domain.UserID) (BulkDeactivateResult, error) {
+func (s *PRService) BulkDeactivateAndReassign(ctx context.Context, teamName domain.TeamName, userIDs []domain.UserID, actor domain.UserID) (BulkDeactivateResult, error) {
 	res := BulkDeactivateResult{
 		TeamName:           teamName,
 		DeactivatedUserIDs: make([]domain.UserID, 0, len(userIDs)),
 		NotReassigned:      make([]BulkNotReassignedPR, 0),
+		Fallbacks:          make([]BulkFallbackReassignment, 0),
 	}
 
 	if _, err := s.teams.GetTeamByName(ctx, teamName); err != nil {
@@ -224,7 +660,7 @@ func (s *PRService) BulkDeactivateAndReassign(ctx context.Context, teamName doma
 		}
 
 		for _, prID := range prIDs {
-			_, _, err := s.ReassignReviewer(ctx, prID, uid)
+			reassigned, err := s.reassign(ctx, ReassignInput{PullRequestID: prID, OldReviewerID: uid, Actor: actor}, domain.AssignmentReasonBulkDeactivate)
 			if err != nil {
 				var derr *domain.DomainError
 				if errors.As(err, &derr) && derr.Code == domain.ErrNoCandidate {
@@ -240,6 +676,14 @@ func (s *PRService) BulkDeactivateAndReassign(ctx context.Context, teamName doma
 			}
 
 			res.ReassignedCount++
+			if reassigned.FallbackTeam != "" {
+				res.Fallbacks = append(res.Fallbacks, BulkFallbackReassignment{
+					PullRequestID: prID,
+					OldUserID:     uid,
+					NewUserID:     reassigned.NewReviewerID,
+					TeamName:      reassigned.FallbackTeam,
+				})
+			}
 		}
 	}
 
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+func TestWithinCapacityHonoursUserOverrides(t *testing.T) {
+	users := []domain.User{
+		{ID: "u1", TeamName: "backend", IsActive: true},
+		{ID: "u2", TeamName: "backend", IsActive: true, MaxOpenReviews: capacity(0)},
+		{ID: "u3", TeamName: "backend", IsActive: true, MaxOpenReviews: capacity(3)},
+	}
+	open := func(id string, reviewers ...domain.UserID) domain.PullRequest {
+		return domain.PullRequest{ID: domain.PullRequestID(id), Status: domain.PRStatusOpen, AssignedReviewers: reviewers}
+	}
+	prs := &memPRs{prs: map[domain.PullRequestID]domain.PullRequest{
+		"pr-1": open("pr-1", "u1", "u2", "u3"),
+		"pr-2": open("pr-2", "u2", "u3"),
+	}}
+	svc := newTestPRService(&memUsers{users: users}, prs, &memTeams{})
+
+	loads, err := svc.reviewerLoads(context.Background(), "backend", users)
+	if err != nil {
+		t.Fatalf("reviewerLoads: %v", err)
+	}
+	available, full := withinCapacity(domain.TeamSettings{TeamName: "backend", MaxOpenReviews: 1}, users, loads)
+
+	var ids []domain.UserID
+	for _, u := range available {
+		ids = append(ids, u.ID)
+	}
+	// u1 hits the team default, u2 is exempt from it and u3 has room under
+	// their own limit.
+	if want := []domain.UserID{"u2", "u3"}; !slices.Equal(ids, want) {
+		t.Errorf("available = %v, want %v", ids, want)
+	}
+	if want := []domain.UserID{"u1"}; !slices.Equal(full, want) {
+		t.Errorf("full = %v, want %v", full, want)
+	}
+}
+
+// countingLoads counts reviewer load queries.
+type countingLoads struct {
+	*memPRs
+	queries int
+}
+
+func (c *countingLoads) GetReviewerLoads(ctx context.Context, reviewerIDs []domain.UserID) (map[domain.UserID]domain.ReviewerLoad, error) {
+	c.queries++
+	return c.memPRs.GetReviewerLoads(ctx, reviewerIDs)
+}
+
+func TestSelectReviewersQueriesLoadsOnce(t *testing.T) {
+	users := &memUsers{users: []domain.User{
+		{ID: "u1", TeamName: "backend", IsActive: true},
+		{ID: "u2", TeamName: "backend", IsActive: true, MaxOpenReviews: capacity(1)},
+		{ID: "u3", TeamName: "backend", IsActive: true},
+		{ID: "u4", TeamName: "backend", IsActive: true},
+		{ID: "u5", TeamName: "backend", IsActive: true},
+	}}
+	// u2 is full, u3 is busier than u4 and u5.
+	prs := &countingLoads{memPRs: &memPRs{prs: map[domain.PullRequestID]domain.PullRequest{
+		"pr-busy": {ID: "pr-busy", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []domain.UserID{"u2", "u3"}},
+	}}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewPRService(logger, users, prs, &seqRand{vals: []int{0}}, &memTeams{}, nil)
+
+	sel, err := svc.selectReviewers(context.Background(), users.users[0], domain.PullRequest{ID: "pr-1", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("selectReviewers: %v", err)
+	}
+	if prs.queries != 1 {
+		t.Errorf("load queries = %d, want 1", prs.queries)
+	}
+	if got := sel.report.Reviewers; !slices.Equal(got, []domain.UserID{"u4", "u5"}) {
+		t.Errorf("reviewers = %v, want [u4 u5]", got)
+	}
+	if !slices.Equal(sel.report.AtCapacity, []domain.UserID{"u2"}) {
+		t.Errorf("at capacity = %v, want [u2]", sel.report.AtCapacity)
+	}
+}
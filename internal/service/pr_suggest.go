@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+// CandidateReason tells why a team member would or would not review a PR.
+type CandidateReason string
+
+const (
+	CandidateCodeOwner   CandidateReason = "code_owner"
+	CandidateSkillMatch  CandidateReason = "skill_match"
+	CandidateStrategy    CandidateReason = "strategy"
+	CandidateFallback    CandidateReason = "fallback_team"
+	CandidateNotSelected CandidateReason = "not_selected"
+
+	CandidateAuthor          CandidateReason = "author"
+	CandidateInactive        CandidateReason = "inactive"
+	CandidateAlreadyAssigned CandidateReason = "already_assigned"
+	CandidateAtCapacity      CandidateReason = "at_capacity"
+	CandidateOutOfOffice     CandidateReason = "out_of_office"
+)
+
+type SuggestInput struct {
+	PullRequestID domain.PullRequestID
+	AuthorID      domain.UserID
+	ChangedFiles  []string
+	Labels        []string
+}
+
+// SuggestedCandidate is one person considered for the PR. Rank orders the
+// eligible candidates, selected ones first in the order they were picked,
+// then the rest by open reviews regardless of the team strategy; excluded
+// candidates have rank 0.
+type SuggestedCandidate struct {
+	UserID      domain.UserID
+	Username    string
+	TeamName    domain.TeamName
+	Rank        int
+	Selected    bool
+	Reason      CandidateReason
+	OpenReviews int
+}
+
+// Suggestion is the result of a dry run of reviewer assignment. Blocker is
+// the error creating the PR would fail with, if any; the candidates are
+// explained either way.
+type Suggestion struct {
+	TeamName   domain.TeamName
+	Report     AssignmentReport
+	Candidates []SuggestedCandidate
+	Blocker    string
+}
+
+// Suggest runs reviewer selection for a new PR, or for the free slots of an
+// existing one, without storing anything, and explains the outcome for
+// every member of the author's team.
+func (s *PRService) Suggest(ctx context.Context, in SuggestInput) (Suggestion, error) {
+	pr := domain.PullRequest{
+		ID:           in.PullRequestID,
+		AuthorID:     in.AuthorID,
+		ChangedFiles: in.ChangedFiles,
+		Labels:       domain.NormalizeTags(in.Labels),
+	}
+
+	if in.PullRequestID != "" {
+		current, err := s.prs.GetByID(ctx, in.PullRequestID)
+		if err != nil {
+			s.logger.Error("get pr for suggestion", slog.String("pr_id", string(in.PullRequestID)), slog.Any("err", err))
+			return Suggestion{}, err
+		}
+		// A draft gets its reviewers when it is marked ready, so it may be
+		// previewed.
+		if current.Status != domain.PRStatusDraft {
+			if err := requireOpen(current, "suggest reviewers for"); err != nil {
+				return Suggestion{}, err
+			}
+		}
+		if in.AuthorID != "" && in.AuthorID != current.AuthorID {
+			return Suggestion{}, domain.NewValidationError("author_id", "does not match the pull request author")
+		}
+		if pr.ChangedFiles == nil {
+			if pr.ChangedFiles, err = s.prs.GetChangedFiles(ctx, current.ID); err != nil {
+				return Suggestion{}, err
+			}
+		}
+		if pr.Labels == nil {
+			pr.Labels = current.Labels
+		}
+		pr.AuthorID = current.AuthorID
+		pr.AssignedReviewers = current.AssignedReviewers
+	}
+
+	if pr.AuthorID == "" {
+		return Suggestion{}, domain.NewValidationError("author_id", "must not be empty")
+	}
+	if err := domain.ValidateTags("labels", pr.Labels); err != nil {
+		return Suggestion{}, err
+	}
+
+	author, err := s.users.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		s.logger.Error("get author for suggestion", slog.String("author_id", string(pr.AuthorID)), slog.Any("err", err))
+		return Suggestion{}, err
+	}
+
+	team, err := s.teams.GetTeamByName(ctx, author.TeamName)
+	if err != nil {
+		return Suggestion{}, err
+	}
+
+	sel, err := s.selectReviewers(ctx, author, pr)
+	if err == nil {
+		err = sel.shortage(len(pr.AssignedReviewers))
+	}
+
+	res := Suggestion{TeamName: team.Name, Report: sel.report}
+	var de *domain.DomainError
+	switch {
+	case errors.As(err, &de):
+		res.Blocker = de.Message
+	case err != nil:
+		return Suggestion{}, err
+	}
+
+	// Candidates and borrowed reviewers come with the loads selection
+	// worked from; only the other members are looked up.
+	loads := maps.Clone(sel.loads)
+	var others []domain.User
+	for _, m := range team.Members {
+		if _, ok := loads[m.ID]; !ok {
+			others = append(others, m)
+		}
+	}
+	if len(others) > 0 {
+		rest, err := s.reviewerLoads(ctx, team.Name, others)
+		if err != nil {
+			return Suggestion{}, err
+		}
+		if loads == nil {
+			loads = rest
+		} else {
+			maps.Copy(loads, rest)
+		}
+	}
+
+	selected := make(map[domain.UserID]CandidateReason, len(sel.report.Reviewers))
+	for _, id := range sel.report.Reviewers {
+		selected[id] = CandidateStrategy
+	}
+	if m := sel.report.LabelMatch; m != nil {
+		for _, sm := range m.Matched {
+			selected[sm.ReviewerID] = CandidateSkillMatch
+		}
+	}
+	for _, o := range sel.report.CodeOwners {
+		selected[o.ReviewerID] = CandidateCodeOwner
+	}
+
+	var eligible, excluded []SuggestedCandidate
+	for _, m := range team.Members {
+		c := SuggestedCandidate{
+			UserID:      m.ID,
+			Username:    m.Username,
+			TeamName:    team.Name,
+			OpenReviews: loads[m.ID].OpenReviews,
+		}
+
+		switch {
+		case m.ID == author.ID:
+			c.Reason = CandidateAuthor
+		case slices.Contains(pr.AssignedReviewers, m.ID):
+			c.Reason = CandidateAlreadyAssigned
+		case !m.IsActive:
+			c.Reason = CandidateInactive
+		case !slices.ContainsFunc(sel.candidates, func(u domain.User) bool { return u.ID == m.ID }):
+			c.Reason = CandidateOutOfOffice
+		case slices.Contains(sel.report.AtCapacity, m.ID):
+			c.Reason = CandidateAtCapacity
+		default:
+			c.Reason = CandidateNotSelected
+			if reason, ok := selected[m.ID]; ok {
+				c.Reason, c.Selected = reason, true
+			}
+			eligible = append(eligible, c)
+			continue
+		}
+		excluded = append(excluded, c)
+	}
+
+	for _, f := range sel.report.Fallbacks {
+		for _, u := range f.members {
+			reason := CandidateFallback
+			if selected[u.ID] == CandidateCodeOwner {
+				reason = CandidateCodeOwner
+			}
+			eligible = append(eligible, SuggestedCandidate{
+				UserID:      u.ID,
+				Username:    u.Username,
+				TeamName:    f.TeamName,
+				Selected:    true,
+				Reason:      reason,
+				OpenReviews: loads[u.ID].OpenReviews,
+			})
+		}
+	}
+
+	// Selected reviewers keep the order they were picked in; the others
+	// follow from the least loaded, whatever the team strategy, as a hint
+	// of who is free rather than who would be picked next.
+	order := func(id domain.UserID) int {
+		if i := slices.Index(sel.report.Reviewers, id); i >= 0 {
+			return i
+		}
+		return len(sel.report.Reviewers)
+	}
+	sort.SliceStable(eligible, func(i, j int) bool {
+		a, b := eligible[i], eligible[j]
+		if oa, ob := order(a.UserID), order(b.UserID); oa != ob {
+			return oa < ob
+		}
+		if a.OpenReviews != b.OpenReviews {
+			return a.OpenReviews < b.OpenReviews
+		}
+		return a.UserID < b.UserID
+	})
+	for i := range eligible {
+		eligible[i].Rank = i + 1
+	}
+
+	res.Candidates = append(eligible, excluded...)
+	return res, nil
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+// suggestTeams builds teams from the users in memory.
+type suggestTeams struct {
+	*memTeams
+	users *memUsers
+}
+
+func (t *suggestTeams) GetTeamByName(_ context.Context, name domain.TeamName) (domain.Team, error) {
+	team := domain.Team{Name: name}
+	for _, u := range t.users.users {
+		if u.TeamName == name {
+			team.Members = append(team.Members, u)
+		}
+	}
+	return team, nil
+}
+
+// countingUsers counts user lookups by id.
+type countingUsers struct {
+	*memUsers
+	lookups int
+}
+
+func (c *countingUsers) GetUserByID(ctx context.Context, id domain.UserID) (domain.User, error) {
+	c.lookups++
+	return c.memUsers.GetUserByID(ctx, id)
+}
+
+func newSuggestService(users *memUsers, prs *memPRs, teams *memTeams) *PRService {
+	svc := newTestPRService(users, prs, teams)
+	svc.teams = &suggestTeams{memTeams: teams, users: users}
+	return svc
+}
+
+func TestSuggestReportsSelectionErrorAsBlocker(t *testing.T) {
+	users, prs, teams := codeownersWorld(domain.CodeownersModeRequire)
+	// p2 is the only owner of deploy/ and is away, so create would fail.
+	users.users[5].IsActive = false
+	svc := newSuggestService(users, prs, teams)
+
+	res, err := svc.Suggest(context.Background(), SuggestInput{AuthorID: "u1", ChangedFiles: []string{"deploy/app.yaml"}})
+	if err != nil {
+		t.Fatalf("Suggest: %v", err)
+	}
+	if !strings.Contains(res.Blocker, "deploy/app.yaml") {
+		t.Errorf("blocker = %q, want the unowned file", res.Blocker)
+	}
+
+	reasons := make(map[domain.UserID]CandidateReason)
+	for _, c := range res.Candidates {
+		reasons[c.UserID] = c.Reason
+		if c.Selected {
+			t.Errorf("%s selected despite the blocker", c.UserID)
+		}
+	}
+	want := map[domain.UserID]CandidateReason{
+		"u1": CandidateAuthor,
+		"u2": CandidateNotSelected,
+		"u3": CandidateNotSelected,
+		"u4": CandidateNotSelected,
+	}
+	for id, reason := range want {
+		if reasons[id] != reason {
+			t.Errorf("%s: reason = %q, want %q", id, reasons[id], reason)
+		}
+	}
+}
+
+func TestSuggestRejectsFinishedPRs(t *testing.T) {
+	users, prs, teams := codeownersWorld(domain.CodeownersModePrefer)
+	prs.prs["pr-merged"] = domain.PullRequest{ID: "pr-merged", AuthorID: "u1", Status: domain.PRStatusMerged}
+	prs.prs["pr-closed"] = domain.PullRequest{ID: "pr-closed", AuthorID: "u1", Status: domain.PRStatusClosed}
+	prs.prs["pr-draft"] = domain.PullRequest{ID: "pr-draft", AuthorID: "u1", Status: domain.PRStatusDraft}
+	svc := newSuggestService(users, prs, teams)
+
+	tests := []struct {
+		id   domain.PullRequestID
+		code domain.ErrorCode
+	}{
+		{"pr-merged", domain.ErrPRMerged},
+		{"pr-closed", domain.ErrPRClosed},
+		{"pr-draft", ""},
+	}
+
+	for _, tt := range tests {
+		_, err := svc.Suggest(context.Background(), SuggestInput{PullRequestID: tt.id})
+		switch {
+		case tt.code == "" && err != nil:
+			t.Errorf("%s: Suggest = %v, want nil", tt.id, err)
+		case tt.code != "" && !domain.IsDomainError(err, tt.code):
+			t.Errorf("%s: Suggest = %v, want %s", tt.id, err, tt.code)
+		}
+	}
+}
+
+func TestSuggestExplainsFallbackReviewersWithoutLookups(t *testing.T) {
+	mem := &memUsers{users: []domain.User{
+		{ID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{ID: "p1", Username: "Pat", TeamName: "platform", IsActive: true},
+	}}
+	users := &countingUsers{memUsers: mem}
+	prs := &memPRs{prs: map[domain.PullRequestID]domain.PullRequest{}}
+	teams := &memTeams{
+		settings:  map[domain.TeamName]domain.TeamSettings{"backend": {TeamName: "backend", MinReviewers: 1, MaxReviewers: 2}},
+		fallbacks: map[domain.TeamName][]domain.TeamName{"backend": {"platform"}},
+	}
+	svc := newSuggestService(mem, prs, teams)
+	svc.users = users
+
+	res, err := svc.Suggest(context.Background(), SuggestInput{AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("Suggest: %v", err)
+	}
+	if users.lookups != 1 {
+		t.Errorf("user lookups = %d, want only the author", users.lookups)
+	}
+
+	var found bool
+	for _, c := range res.Candidates {
+		if c.UserID != "p1" {
+			continue
+		}
+		found = true
+		if c.Username != "Pat" || c.TeamName != "platform" || c.Reason != CandidateFallback || !c.Selected || c.Rank != 1 {
+			t.Errorf("p1 = %+v, want selected Pat from platform as fallback_team with rank 1", c)
+		}
+	}
+	if !found {
+		t.Errorf("candidates = %+v, want p1 among them", res.Candidates)
+	}
+}
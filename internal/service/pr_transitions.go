@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+func (s *PRService) Close(ctx context.Context, id domain.PullRequestID, actor domain.UserID) (domain.PullRequest, error) {
+	pr, err := s.getForTransition(ctx, id)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	switch pr.Status {
+	case domain.PRStatusClosed:
+		return pr, nil
+	case domain.PRStatusMerged:
+		return domain.PullRequest{}, domain.NewDomainError(domain.ErrPRMerged, "cannot close merged PR")
+	}
+
+	updated, err := s.prs.SetClosed(ctx, id, time.Now().UTC(), actor)
+	if err != nil {
+		s.logger.Error("close pr", slog.String("pr_id", string(id)), slog.Any("err", err))
+		return domain.PullRequest{}, err
+	}
+
+	return updated, nil
+}
+
+// Reopen moves a closed PR back to OPEN. Reviewers are kept; a PR that was
+// closed while still a draft gets its reviewers assigned now, and only then
+// is the assignment report returned.
+func (s *PRService) Reopen(ctx context.Context, id domain.PullRequestID, actor domain.UserID) (domain.PullRequest, *AssignmentReport, error) {
+	pr, err := s.getForTransition(ctx, id)
+	if err != nil {
+		return domain.PullRequest{}, nil, err
+	}
+
+	switch pr.Status {
+	case domain.PRStatusOpen:
+		return pr, nil, nil
+	case domain.PRStatusMerged:
+		return domain.PullRequest{}, nil, domain.NewDomainError(domain.ErrPRMerged, "cannot reopen merged PR")
+	case domain.PRStatusDraft:
+		return domain.PullRequest{}, nil, domain.NewDomainError(domain.ErrPRDraft, "draft PR is not closed")
+	}
+
+	return s.open(ctx, pr, actor)
+}
+
+// MarkReady turns a draft into an OPEN PR and assigns its reviewers. The
+// assignment report is nil if the PR was already open.
+func (s *PRService) MarkReady(ctx context.Context, id domain.PullRequestID, actor domain.UserID) (domain.PullRequest, *AssignmentReport, error) {
+	pr, err := s.getForTransition(ctx, id)
+	if err != nil {
+		return domain.PullRequest{}, nil, err
+	}
+
+	switch pr.Status {
+	case domain.PRStatusOpen:
+		return pr, nil, nil
+	case domain.PRStatusMerged:
+		return domain.PullRequest{}, nil, domain.NewDomainError(domain.ErrPRMerged, "merged PR is not a draft")
+	case domain.PRStatusClosed:
+		return domain.PullRequest{}, nil, domain.NewDomainError(domain.ErrPRClosed, "closed PR must be reopened instead")
+	}
+
+	return s.open(ctx, pr, actor)
+}
+
+// open moves pr to OPEN. Without an explicit actor the author is recorded,
+// as on create. A PR without reviewers gets them assigned, and the report
+// of that assignment is returned; otherwise the report is nil.
+func (s *PRService) open(ctx context.Context, pr domain.PullRequest, actor domain.UserID) (domain.PullRequest, *AssignmentReport, error) {
+	if actor == "" {
+		actor = pr.AuthorID
+	}
+
+	var report *AssignmentReport
+
+	if len(pr.AssignedReviewers) == 0 {
+		author, err := s.users.GetUserByID(ctx, pr.AuthorID)
+		if err != nil {
+			s.logger.Error("get author for pr", slog.String("author_id", string(pr.AuthorID)), slog.Any("err", err))
+			return domain.PullRequest{}, nil, err
+		}
+
+		files, err := s.prs.GetChangedFiles(ctx, pr.ID)
+		if err != nil {
+			s.logger.Error("get changed files of pr", slog.String("pr_id", string(pr.ID)), slog.Any("err", err))
+			return domain.PullRequest{}, nil, err
+		}
+
+		pr.ChangedFiles = files
+		selected, _, err := s.selectInitialReviewers(ctx, author, pr)
+		if err != nil {
+			return domain.PullRequest{}, nil, err
+		}
+		report = &selected
+	}
+
+	var assigned []domain.UserID
+	if report != nil {
+		assigned = report.Reviewers
+	}
+
+	updated, err := s.prs.SetOpen(ctx, pr.ID, assigned, actor)
+	if err != nil {
+		s.logger.Error("open pr", slog.String("pr_id", string(pr.ID)), slog.Any("err", err))
+		return domain.PullRequest{}, nil, err
+	}
+
+	if report != nil {
+		s.enqueueUnfilled(ctx, pr.ID, *report)
+	}
+
+	return updated, report, nil
+}
+
+func (s *PRService) getForTransition(ctx context.Context, id domain.PullRequestID) (domain.PullRequest, error) {
+	if id == "" {
+		return domain.PullRequest{}, domain.NewValidationError("pull_request_id", "must not be empty")
+	}
+
+	pr, err := s.prs.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("get pr before status change", slog.String("pr_id", string(id)), slog.Any("err", err))
+		return domain.PullRequest{}, err
+	}
+
+	return pr, nil
+}
+
+func requireOpen(pr domain.PullRequest, action string) error {
+	return pr.Status.RequireOpen(action)
+}
+
+// externalMergeDetails marks merged events recorded by MarkMerged.
+const externalMergeDetails = "external"
+
+// MarkMerged records a merge that already happened elsewhere. The merge cannot
+// be refused, so it deliberately overrides the team merge policy: unmet
+// conditions, or the failure to check them, are kept in the details of the
+// merged event.
+func (s *PRService) MarkMerged(ctx context.Context, id domain.PullRequestID, mergedAt time.Time, actor domain.UserID) (domain.PullRequest, error) {
+	pr, err := s.getForTransition(ctx, id)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	if pr.Status == domain.PRStatusMerged {
+		return pr, nil
+	}
+
+	details := externalMergeDetails
+	blockers, err := s.mergeBlockers(ctx, pr)
+	if err != nil {
+		details += "; policy not checked"
+	} else if len(blockers) > 0 {
+		overridden := domain.JoinMergeBlockers(blockers)
+		s.logger.Warn("external merge overrides merge policy", slog.String("pr_id", string(id)), slog.String("blockers", overridden))
+		details += "; policy overridden: " + overridden
+	}
+
+	updated, err := s.prs.SetMerged(ctx, id, mergedAt, actor, details, nil)
+	if err != nil {
+		s.logger.Error("mark pr merged", slog.String("pr_id", string(id)), slog.Any("err", err))
+		return domain.PullRequest{}, err
+	}
+
+	return updated, nil
+}
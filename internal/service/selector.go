@@ -0,0 +1,160 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+	"github.com/freeholder/pr-reviewer-service/internal/random"
+)
+
+type ReviewerCandidate struct {
+	User domain.User
+	Load domain.ReviewerLoad
+}
+
+// ReviewerSelector picks up to limit reviewers out of the given candidates.
+// Candidates are already filtered (active, not the author, not assigned).
+type ReviewerSelector interface {
+	Select(candidates []ReviewerCandidate, limit int) []domain.UserID
+}
+
+func NewReviewerSelector(strategy domain.ReviewerStrategy, rand random.Randomizer) ReviewerSelector {
+	switch strategy {
+	case domain.ReviewerStrategyRandom:
+		return RandomSelector{rand: rand}
+	case domain.ReviewerStrategyRoundRobin:
+		return RoundRobinSelector{}
+	case domain.ReviewerStrategyWeightedRandom:
+		return WeightedRandomSelector{rand: rand}
+	default:
+		return LeastLoadedSelector{rand: rand}
+	}
+}
+
+type RandomSelector struct {
+	rand random.Randomizer
+}
+
+func (s RandomSelector) Select(candidates []ReviewerCandidate, limit int) []domain.UserID {
+	n := len(candidates)
+	if n == 0 || limit <= 0 {
+		return nil
+	}
+	if n <= limit {
+		return candidateIDs(candidates)
+	}
+
+	used := make(map[int]struct{}, limit)
+	var result []domain.UserID
+
+	for len(result) < limit {
+		i := s.rand.Intn(n)
+		if _, exists := used[i]; exists {
+			continue
+		}
+		used[i] = struct{}{}
+		result = append(result, candidates[i].User.ID)
+	}
+
+	return result
+}
+
+// LeastLoadedSelector prefers candidates with the fewest open reviews.
+// Candidates with equal load are picked in random order.
+type LeastLoadedSelector struct {
+	rand random.Randomizer
+}
+
+func (s LeastLoadedSelector) Select(candidates []ReviewerCandidate, limit int) []domain.UserID {
+	if len(candidates) == 0 || limit <= 0 {
+		return nil
+	}
+
+	sorted := append([]ReviewerCandidate(nil), candidates...)
+	for i := len(sorted) - 1; i > 0; i-- {
+		j := s.rand.Intn(i + 1)
+		sorted[i], sorted[j] = sorted[j], sorted[i]
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Load.OpenReviews < sorted[j].Load.OpenReviews
+	})
+
+	return candidateIDs(sorted[:min(limit, len(sorted))])
+}
+
+// RoundRobinSelector picks the candidates who were assigned least recently,
+// so over time every member of the team gets their turn.
+type RoundRobinSelector struct{}
+
+func (RoundRobinSelector) Select(candidates []ReviewerCandidate, limit int) []domain.UserID {
+	if len(candidates) == 0 || limit <= 0 {
+		return nil
+	}
+
+	sorted := append([]ReviewerCandidate(nil), candidates...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].Load.LastAssignedAt, sorted[j].Load.LastAssignedAt
+		switch {
+		case a == nil && b != nil:
+			return true
+		case a != nil && b == nil:
+			return false
+		case a != nil && b != nil && !a.Equal(*b):
+			return a.Before(*b)
+		}
+		return sorted[i].User.ID < sorted[j].User.ID
+	})
+
+	return candidateIDs(sorted[:min(limit, len(sorted))])
+}
+
+// WeightedRandomSelector picks randomly, but the fewer open reviews
+// a candidate has the more likely they are to be picked.
+type WeightedRandomSelector struct {
+	rand random.Randomizer
+}
+
+func (s WeightedRandomSelector) Select(candidates []ReviewerCandidate, limit int) []domain.UserID {
+	n := len(candidates)
+	if n == 0 || limit <= 0 {
+		return nil
+	}
+	if n <= limit {
+		return candidateIDs(candidates)
+	}
+
+	maxLoad := 0
+	for _, c := range candidates {
+		maxLoad = max(maxLoad, c.Load.OpenReviews)
+	}
+
+	pool := append([]ReviewerCandidate(nil), candidates...)
+	var result []domain.UserID
+
+	for len(result) < limit {
+		total := 0
+		for _, c := range pool {
+			total += maxLoad - c.Load.OpenReviews + 1
+		}
+
+		pick := s.rand.Intn(total)
+		for i, c := range pool {
+			pick -= maxLoad - c.Load.OpenReviews + 1
+			if pick < 0 {
+				result = append(result, c.User.ID)
+				pool = append(pool[:i], pool[i+1:]...)
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+func candidateIDs(candidates []ReviewerCandidate) []domain.UserID {
+	ids := make([]domain.UserID, 0, len(candidates))
+	for _, c := range candidates {
+		ids = append(ids, c.User.ID)
+	}
+	return ids
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+// seqRand returns the given values in turn, reduced modulo n.
+type seqRand struct {
+	vals []int
+	i    int
+}
+
+func (r *seqRand) Intn(n int) int {
+	v := r.vals[r.i%len(r.vals)]
+	r.i++
+	return v % n
+}
+
+func candidate(id string, load int, lastAssigned *time.Time) ReviewerCandidate {
+	return ReviewerCandidate{
+		User: domain.User{ID: domain.UserID(id)},
+		Load: domain.ReviewerLoad{UserID: domain.UserID(id), OpenReviews: load, LastAssignedAt: lastAssigned},
+	}
+}
+
+func TestSelectorsReturnWholePoolWhenLimitCoversIt(t *testing.T) {
+	pool := []ReviewerCandidate{candidate("a", 2, nil), candidate("b", 0, nil), candidate("c", 1, nil)}
+
+	for _, strategy := range []domain.ReviewerStrategy{
+		domain.ReviewerStrategyRandom,
+		domain.ReviewerStrategyLeastLoaded,
+		domain.ReviewerStrategyRoundRobin,
+		domain.ReviewerStrategyWeightedRandom,
+	} {
+		for _, limit := range []int{3, 5} {
+			got := NewReviewerSelector(strategy, &seqRand{vals: []int{0}}).Select(pool, limit)
+			slices.Sort(got)
+			if want := []domain.UserID{"a", "b", "c"}; !slices.Equal(got, want) {
+				t.Errorf("%s limit %d: got %v, want %v", strategy, limit, got, want)
+			}
+		}
+	}
+}
+
+func TestSelectorsEmptyPoolOrZeroLimit(t *testing.T) {
+	pool := []ReviewerCandidate{candidate("a", 0, nil)}
+	for _, strategy := range []domain.ReviewerStrategy{
+		domain.ReviewerStrategyRandom,
+		domain.ReviewerStrategyLeastLoaded,
+		domain.ReviewerStrategyRoundRobin,
+		domain.ReviewerStrategyWeightedRandom,
+	} {
+		sel := NewReviewerSelector(strategy, &seqRand{vals: []int{0}})
+		if got := sel.Select(nil, 2); got != nil {
+			t.Errorf("%s empty pool: got %v", strategy, got)
+		}
+		if got := sel.Select(pool, 0); got != nil {
+			t.Errorf("%s zero limit: got %v", strategy, got)
+		}
+	}
+}
+
+func TestRandomSelectorSkipsRepeatedDraws(t *testing.T) {
+	pool := []ReviewerCandidate{candidate("a", 0, nil), candidate("b", 0, nil), candidate("c", 0, nil)}
+
+	got := RandomSelector{rand: &seqRand{vals: []int{2, 2, 0}}}.Select(pool, 2)
+	if want := []domain.UserID{"c", "a"}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestLeastLoadedSelector(t *testing.T) {
+	tests := []struct {
+		name  string
+		rand  []int
+		pool  []ReviewerCandidate
+		limit int
+		want  []domain.UserID
+	}{
+		{
+			name:  "lowest load first",
+			rand:  []int{0},
+			pool:  []ReviewerCandidate{candidate("a", 3, nil), candidate("b", 1, nil), candidate("c", 2, nil)},
+			limit: 2,
+			want:  []domain.UserID{"b", "c"},
+		},
+		{
+			// Shuffle with j=0: [a b c] -> [c b a] -> [b c a].
+			name:  "ties broken by shuffle",
+			rand:  []int{0},
+			pool:  []ReviewerCandidate{candidate("a", 0, nil), candidate("b", 0, nil), candidate("c", 0, nil)},
+			limit: 1,
+			want:  []domain.UserID{"b"},
+		},
+		{
+			// Shuffle with j=i keeps the order.
+			name:  "ties kept in order without swaps",
+			rand:  []int{2, 1},
+			pool:  []ReviewerCandidate{candidate("a", 0, nil), candidate("b", 0, nil), candidate("c", 0, nil)},
+			limit: 1,
+			want:  []domain.UserID{"a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LeastLoadedSelector{rand: &seqRand{vals: tt.rand}}.Select(tt.pool, tt.limit)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundRobinSelector(t *testing.T) {
+	older := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	pool := []ReviewerCandidate{
+		candidate("d", 0, &newer),
+		candidate("c", 0, &older),
+		candidate("b", 5, nil),
+		candidate("a", 0, &older),
+	}
+
+	got := RoundRobinSelector{}.Select(pool, 3)
+	// Never assigned first, then least recently assigned, ties by id.
+	if want := []domain.UserID{"b", "a", "c"}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestWeightedRandomSelector(t *testing.T) {
+	// Weights are maxLoad-load+1: a=1, b=3, c=2 (total 6).
+	pool := []ReviewerCandidate{candidate("a", 2, nil), candidate("b", 0, nil), candidate("c", 1, nil)}
+
+	tests := []struct {
+		name string
+		rand []int
+		want []domain.UserID
+	}{
+		{"first bucket", []int{0, 0}, []domain.UserID{"a", "b"}},
+		{"heaviest bucket", []int{3, 0}, []domain.UserID{"b", "a"}},
+		{"last bucket", []int{5, 2}, []domain.UserID{"c", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WeightedRandomSelector{rand: &seqRand{vals: tt.rand}}.Select(pool, 2)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
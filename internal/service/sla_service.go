@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+	"github.com/freeholder/pr-reviewer-service/internal/notify"
+)
+
+const (
+	slaBatchSize  = 50
+	slaRetryDelay = 15 * time.Minute
+)
+
+// SLAService watches pending reviews of open PRs: once an assignment is
+// older than the team's reminder threshold the reviewer is reminded, and
+// after the escalation threshold the review is reassigned to someone else.
+type SLAService struct {
+	logger   *slog.Logger
+	prs      PullRequestRepository
+	users    UserRepository
+	prSvc    *PRService
+	notifier notify.Notifier
+}
+
+func NewSLAService(logger *slog.Logger, prs PullRequestRepository, users UserRepository, prSvc *PRService, notifier notify.Notifier) *SLAService {
+	return &SLAService{
+		logger:   logger,
+		prs:      prs,
+		users:    users,
+		prSvc:    prSvc,
+		notifier: notifier,
+	}
+}
+
+func (s *SLAService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.CheckDue(ctx, time.Now()); err != nil {
+				s.logger.Error("check review sla", slog.Any("err", err))
+			}
+		}
+	}
+}
+
+// CheckDue handles one batch of reviews that are due at now and returns
+// how many were looked at. A review whose reminder or escalation failed is
+// set aside for slaRetryDelay, so it does not keep the reviews behind it
+// out of the batch.
+func (s *SLAService) CheckDue(ctx context.Context, now time.Time) (int, error) {
+	pending, err := s.prs.ListPendingReviews(ctx, now, slaBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, p := range pending {
+		switch p.Due(now) {
+		case domain.SLAActionEscalate:
+			s.escalate(ctx, p, now)
+		case domain.SLAActionRemind:
+			s.remind(ctx, p, now)
+		}
+	}
+
+	return len(pending), nil
+}
+
+func (s *SLAService) remind(ctx context.Context, p domain.PendingReview, now time.Time) {
+	log := s.logger.With(slog.String("pr_id", string(p.PullRequestID)), slog.String("user_id", string(p.ReviewerID)))
+
+	msg, err := s.reminder(ctx, p)
+	if err != nil {
+		log.Error("build review reminder", slog.Any("err", err))
+		s.postpone(ctx, log, p, now)
+		return
+	}
+
+	// A reminder that reached some channels counts as sent: retrying it
+	// would repeat it on the channels that already delivered it.
+	var partial *notify.PartialError
+	if err := s.notifier.Notify(ctx, msg); errors.As(err, &partial) {
+		log.Warn("review reminder not sent on every channel", slog.String("notifier", s.notifier.Name()), slog.Any("err", err))
+	} else if err != nil {
+		log.Error("send review reminder", slog.String("notifier", s.notifier.Name()), slog.Any("err", err))
+		s.postpone(ctx, log, p, now)
+		return
+	}
+
+	if err := s.prs.MarkReminded(ctx, p.PullRequestID, p.ReviewerID, now); err != nil {
+		log.Error("mark review reminded", slog.Any("err", err))
+		return
+	}
+
+	log.Info("review reminder sent", slog.Time("since", p.Since))
+}
+
+func (s *SLAService) reminder(ctx context.Context, p domain.PendingReview) (notify.Message, error) {
+	pr, err := s.prs.GetByID(ctx, p.PullRequestID)
+	if err != nil {
+		return notify.Message{}, err
+	}
+	reviewer, err := s.users.GetUserByID(ctx, p.ReviewerID)
+	if err != nil {
+		return notify.Message{}, err
+	}
+	author, err := s.users.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		return notify.Message{}, err
+	}
+
+	return notify.Message{
+		Kind:      notify.KindReminder,
+		Recipient: reviewer,
+		PR:        pr,
+		Author:    author,
+		Since:     p.Since,
+	}, nil
+}
+
+func (s *SLAService) escalate(ctx context.Context, p domain.PendingReview, now time.Time) {
+	log := s.logger.With(slog.String("pr_id", string(p.PullRequestID)), slog.String("user_id", string(p.ReviewerID)))
+
+	in := ReassignInput{PullRequestID: p.PullRequestID, OldReviewerID: p.ReviewerID}
+	res, err := s.prSvc.reassign(ctx, in, domain.AssignmentReasonSLAEscalation)
+	if err == nil {
+		log.Info("review escalated", slog.String("new_user_id", string(res.NewReviewerID)), slog.String("fallback_team", string(res.FallbackTeam)), slog.Time("since", p.Since))
+		return
+	}
+
+	var de *domain.DomainError
+	if !errors.As(err, &de) {
+		log.Error("escalate review", slog.Any("err", err))
+		s.postpone(ctx, log, p, now)
+		return
+	}
+
+	// Nobody can take the review over; do not try again for this assignment.
+	log.Warn("review escalation skipped", slog.String("code", string(de.Code)), slog.String("reason", de.Message))
+	if err := s.prs.MarkEscalated(ctx, p.PullRequestID, p.ReviewerID, now); err != nil {
+		log.Error("mark review escalated", slog.Any("err", err))
+	}
+}
+
+func (s *SLAService) postpone(ctx context.Context, log *slog.Logger, p domain.PendingReview, now time.Time) {
+	if err := s.prs.PostponeSLA(ctx, p.PullRequestID, p.ReviewerID, now.Add(slaRetryDelay)); err != nil {
+		log.Error("postpone review sla", slog.Any("err", err))
+	}
+}
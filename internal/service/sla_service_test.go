@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+	"github.com/freeholder/pr-reviewer-service/internal/notify"
+)
+
+type slaKey struct {
+	pr       domain.PullRequestID
+	reviewer domain.UserID
+}
+
+// fakeSLARepo keeps pending reviews in memory and lists them the way
+// ListPendingReviews does: due at now, not postponed, oldest first.
+type fakeSLARepo struct {
+	PullRequestRepository
+
+	prs       map[domain.PullRequestID]domain.PullRequest
+	broken    map[domain.PullRequestID]bool
+	reviews   []domain.PendingReview
+	retryAt   map[slaKey]time.Time
+	reminded  []slaKey
+	escalated []slaKey
+}
+
+func (f *fakeSLARepo) ListPendingReviews(_ context.Context, now time.Time, limit int) ([]domain.PendingReview, error) {
+	var due []domain.PendingReview
+	for _, r := range f.reviews {
+		if t, ok := f.retryAt[slaKey{r.PullRequestID, r.ReviewerID}]; ok && t.After(now) {
+			continue
+		}
+		if r.Due(now) != domain.SLAActionNone {
+			due = append(due, r)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].Since.Before(due[j].Since) })
+	return due[:min(limit, len(due))], nil
+}
+
+func (f *fakeSLARepo) GetByID(_ context.Context, id domain.PullRequestID) (domain.PullRequest, error) {
+	if f.broken[id] {
+		return domain.PullRequest{}, errors.New("connection reset")
+	}
+	pr, ok := f.prs[id]
+	if !ok {
+		return domain.PullRequest{}, domain.NewDomainError(domain.ErrNotFound, "pull request not found")
+	}
+	return pr, nil
+}
+
+func (f *fakeSLARepo) mark(prID domain.PullRequestID, reviewerID domain.UserID, at time.Time, escalated bool) {
+	for i := range f.reviews {
+		r := &f.reviews[i]
+		if r.PullRequestID == prID && r.ReviewerID == reviewerID {
+			if escalated {
+				r.EscalatedAt = &at
+			} else {
+				r.RemindedAt = &at
+			}
+		}
+	}
+}
+
+func (f *fakeSLARepo) MarkReminded(_ context.Context, prID domain.PullRequestID, reviewerID domain.UserID, at time.Time) error {
+	f.mark(prID, reviewerID, at, false)
+	f.reminded = append(f.reminded, slaKey{prID, reviewerID})
+	return nil
+}
+
+func (f *fakeSLARepo) MarkEscalated(_ context.Context, prID domain.PullRequestID, reviewerID domain.UserID, at time.Time) error {
+	f.mark(prID, reviewerID, at, true)
+	f.escalated = append(f.escalated, slaKey{prID, reviewerID})
+	return nil
+}
+
+func (f *fakeSLARepo) PostponeSLA(_ context.Context, prID domain.PullRequestID, reviewerID domain.UserID, until time.Time) error {
+	if f.retryAt == nil {
+		f.retryAt = make(map[slaKey]time.Time)
+	}
+	f.retryAt[slaKey{prID, reviewerID}] = until
+	return nil
+}
+
+type fakeSLAUsers struct {
+	UserRepository
+}
+
+func (fakeSLAUsers) GetUserByID(_ context.Context, id domain.UserID) (domain.User, error) {
+	return domain.User{ID: id, Username: string(id)}, nil
+}
+
+// pickyNotifier fails for the listed recipients and records the rest.
+type pickyNotifier struct {
+	failFor []domain.UserID
+	sent    []domain.UserID
+}
+
+func (n *pickyNotifier) Name() string { return "picky" }
+
+func (n *pickyNotifier) Notify(_ context.Context, m notify.Message) error {
+	if slices.Contains(n.failFor, m.Recipient.ID) {
+		return errors.New("unavailable")
+	}
+	n.sent = append(n.sent, m.Recipient.ID)
+	return nil
+}
+
+func newTestSLAService(repo *fakeSLARepo, n notify.Notifier) *SLAService {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	users := fakeSLAUsers{}
+	return NewSLAService(logger, repo, users, NewPRService(logger, users, repo, &seqRand{vals: []int{0}}, nil, nil), n)
+}
+
+func TestCheckDueEscalatesBeforeReminding(t *testing.T) {
+	now := time.Date(2025, 12, 10, 9, 0, 0, 0, time.UTC)
+	sla := func(pr, reviewer string, age time.Duration) domain.PendingReview {
+		return domain.PendingReview{
+			PullRequestID: domain.PullRequestID(pr),
+			ReviewerID:    domain.UserID(reviewer),
+			Since:         now.Add(-age),
+			ReminderAfter: 24 * time.Hour,
+			EscalateAfter: 72 * time.Hour,
+		}
+	}
+
+	repo := &fakeSLARepo{
+		// u2 is no longer on pr-1, so its escalation finds nobody to replace.
+		prs: map[domain.PullRequestID]domain.PullRequest{
+			"pr-1": {ID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen},
+			"pr-2": {ID: "pr-2", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []domain.UserID{"u3"}},
+		},
+		reviews: []domain.PendingReview{
+			sla("pr-2", "u3", 30*time.Hour),
+			sla("pr-1", "u2", 80*time.Hour),
+			sla("pr-2", "u4", 2*time.Hour),
+		},
+	}
+	n := &pickyNotifier{}
+
+	got, err := newTestSLAService(repo, n).CheckDue(context.Background(), now)
+	if err != nil {
+		t.Fatalf("CheckDue: %v", err)
+	}
+	if got != 2 {
+		t.Fatalf("CheckDue looked at %d reviews, want 2", got)
+	}
+
+	if want := []slaKey{{"pr-1", "u2"}}; !slices.Equal(repo.escalated, want) {
+		t.Errorf("escalated = %v, want %v", repo.escalated, want)
+	}
+	if want := []slaKey{{"pr-2", "u3"}}; !slices.Equal(repo.reminded, want) {
+		t.Errorf("reminded = %v, want %v", repo.reminded, want)
+	}
+	if want := []domain.UserID{"u3"}; !slices.Equal(n.sent, want) {
+		t.Errorf("reminders sent to %v, want %v", n.sent, want)
+	}
+
+	// Nobody took the escalated review over, so its reviewer still gets the
+	// reminder, once.
+	svc := newTestSLAService(repo, n)
+	for range 2 {
+		if _, err := svc.CheckDue(context.Background(), now); err != nil {
+			t.Fatalf("CheckDue: %v", err)
+		}
+	}
+	if want := []slaKey{{"pr-2", "u3"}, {"pr-1", "u2"}}; !slices.Equal(repo.reminded, want) {
+		t.Errorf("reminded = %v, want %v", repo.reminded, want)
+	}
+	if len(repo.escalated) != 1 {
+		t.Errorf("escalated = %v, want one attempt", repo.escalated)
+	}
+}
+
+func TestCheckDueSetsFailedReviewsAside(t *testing.T) {
+	now := time.Date(2025, 12, 10, 9, 0, 0, 0, time.UTC)
+
+	repo := &fakeSLARepo{
+		prs:    map[domain.PullRequestID]domain.PullRequest{},
+		broken: map[domain.PullRequestID]bool{"pr-broken": true},
+	}
+	var failing []domain.UserID
+	for i := range slaBatchSize - 1 {
+		id := domain.UserID(fmt.Sprintf("bad-%d", i))
+		prID := domain.PullRequestID(fmt.Sprintf("pr-%d", i))
+		failing = append(failing, id)
+		repo.prs[prID] = domain.PullRequest{ID: prID, AuthorID: "u1", Status: domain.PRStatusOpen}
+		repo.reviews = append(repo.reviews, domain.PendingReview{
+			PullRequestID: prID, ReviewerID: id, Since: now.Add(-48*time.Hour - time.Duration(i)*time.Minute), ReminderAfter: 24 * time.Hour,
+		})
+	}
+	// An escalation that fails with an infrastructure error.
+	repo.reviews = append(repo.reviews, domain.PendingReview{
+		PullRequestID: "pr-broken", ReviewerID: "u9", Since: now.Add(-100 * time.Hour), EscalateAfter: 72 * time.Hour,
+	})
+	// The youngest review does not fit into the first batch.
+	repo.prs["pr-late"] = domain.PullRequest{ID: "pr-late", AuthorID: "u1", Status: domain.PRStatusOpen}
+	repo.reviews = append(repo.reviews, domain.PendingReview{
+		PullRequestID: "pr-late", ReviewerID: "u5", Since: now.Add(-25 * time.Hour), ReminderAfter: 24 * time.Hour,
+	})
+
+	n := &pickyNotifier{failFor: failing}
+	svc := newTestSLAService(repo, n)
+
+	if got, err := svc.CheckDue(context.Background(), now); err != nil || got != slaBatchSize {
+		t.Fatalf("first CheckDue = %d, %v; want %d, nil", got, err, slaBatchSize)
+	}
+	if len(repo.reminded) != 0 || len(repo.escalated) != 0 {
+		t.Fatalf("after failures: reminded=%v escalated=%v", repo.reminded, repo.escalated)
+	}
+	if len(repo.retryAt) != slaBatchSize {
+		t.Fatalf("postponed %d reviews, want %d", len(repo.retryAt), slaBatchSize)
+	}
+
+	if got, err := svc.CheckDue(context.Background(), now.Add(time.Minute)); err != nil || got != 1 {
+		t.Fatalf("second CheckDue = %d, %v; want 1, nil", got, err)
+	}
+	if want := []slaKey{{"pr-late", "u5"}}; !slices.Equal(repo.reminded, want) {
+		t.Fatalf("reminded = %v, want %v", repo.reminded, want)
+	}
+
+	// After the delay the failed reviews are due again.
+	if got, _ := svc.CheckDue(context.Background(), now.Add(slaRetryDelay)); got != slaBatchSize {
+		t.Fatalf("CheckDue after the retry delay = %d, want %d", got, slaBatchSize)
+	}
+}
+
+func TestCheckDueTreatsPartiallySentReminderAsSent(t *testing.T) {
+	now := time.Date(2025, 12, 10, 9, 0, 0, 0, time.UTC)
+
+	repo := &fakeSLARepo{
+		prs: map[domain.PullRequestID]domain.PullRequest{
+			"pr-1": {ID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen},
+		},
+		reviews: []domain.PendingReview{
+			{PullRequestID: "pr-1", ReviewerID: "u2", Since: now.Add(-30 * time.Hour), ReminderAfter: 24 * time.Hour},
+		},
+	}
+	// Slack delivers the reminder while email is down.
+	slack, email := &pickyNotifier{}, &pickyNotifier{failFor: []domain.UserID{"u2"}}
+	svc := newTestSLAService(repo, notify.Multi{slack, email})
+
+	for range 2 {
+		if _, err := svc.CheckDue(context.Background(), now.Add(slaRetryDelay)); err != nil {
+			t.Fatalf("CheckDue: %v", err)
+		}
+	}
+	if want := []slaKey{{"pr-1", "u2"}}; !slices.Equal(repo.reminded, want) {
+		t.Errorf("reminded = %v, want %v", repo.reminded, want)
+	}
+	if len(repo.retryAt) != 0 {
+		t.Errorf("postponed = %v, want nothing", repo.retryAt)
+	}
+	if want := []domain.UserID{"u2"}; !slices.Equal(slack.sent, want) {
+		t.Errorf("slack sent to %v, want %v", slack.sent, want)
+	}
+}
@@ -2,12 +2,17 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/freeholder/pr-reviewer-service/internal/domain"
 )
 
 type StatsRepository interface {
-	GetReviewerStats(ctx context.Context) ([]domain.ReviewerStats, error)
+	GetReviewerStats(ctx context.Context, filter domain.ReviewerStatsFilter) ([]domain.ReviewerStats, error)
+	GetTeamLatency(ctx context.Context, filter domain.LatencyFilter) ([]domain.TeamLatency, error)
+	GetReviewerLatency(ctx context.Context, filter domain.LatencyFilter) ([]domain.ReviewerLatency, error)
+	GetMemberActivity(ctx context.Context, teamName domain.TeamName, to time.Time) ([]domain.MemberActivity, error)
+	GetAssignmentCounts(ctx context.Context, from, to time.Time, teamName domain.TeamName) (map[domain.UserID]int64, error)
 }
 
 type StatsService struct {
@@ -18,6 +23,64 @@ func NewStatsService(statsRepo StatsRepository) *StatsService {
 	return &StatsService{statsRepo: statsRepo}
 }
 
-func (s *StatsService) GetReviewerStats(ctx context.Context) ([]domain.ReviewerStats, error) {
-	return s.statsRepo.GetReviewerStats(ctx)
+func (s *StatsService) GetReviewerStats(ctx context.Context, filter domain.ReviewerStatsFilter) ([]domain.ReviewerStats, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.statsRepo.GetReviewerStats(ctx, filter)
+}
+
+func (s *StatsService) GetLatency(ctx context.Context, filter domain.LatencyFilter) (domain.LatencyReport, error) {
+	if err := filter.Validate(); err != nil {
+		return domain.LatencyReport{}, err
+	}
+
+	teams, err := s.statsRepo.GetTeamLatency(ctx, filter)
+	if err != nil {
+		return domain.LatencyReport{}, err
+	}
+
+	reviewers, err := s.statsRepo.GetReviewerLatency(ctx, filter)
+	if err != nil {
+		return domain.LatencyReport{}, err
+	}
+
+	return domain.LatencyReport{Teams: teams, Reviewers: reviewers}, nil
+}
+
+func (s *StatsService) GetFairness(ctx context.Context, filter domain.FairnessFilter) ([]domain.TeamFairness, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	to := time.Now()
+	if filter.To != nil {
+		to = *filter.To
+	}
+	from := to.Add(-domain.DefaultFairnessWindow)
+	if filter.From != nil {
+		from = *filter.From
+	}
+
+	members, err := s.statsRepo.GetMemberActivity(ctx, filter.TeamName, to)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.statsRepo.GetAssignmentCounts(ctx, from, to, filter.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []domain.TeamFairness
+	for start := 0; start < len(members); {
+		end := start
+		for end < len(members) && members[end].TeamName == members[start].TeamName {
+			end++
+		}
+		result = append(result, domain.NewTeamFairness(members[start].TeamName, members[start:end], counts, from, to))
+		start = end
+	}
+
+	return result, nil
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/freeholder/pr-reviewer-service/internal/codeowners"
 	"github.com/freeholder/pr-reviewer-service/internal/domain"
 )
 
@@ -23,6 +24,10 @@ func NewTeamService(logger *slog.Logger, teams TeamRepository, users UserReposit
 }
 
 func (s *TeamService) AddTeam(ctx context.Context, team domain.Team) (domain.Team, error) {
+	for i := range team.Members {
+		team.Members[i].Skills = domain.NormalizeTags(team.Members[i].Skills)
+	}
+
 	if err := team.Validate(); err != nil {
 		return domain.Team{}, err
 	}
@@ -58,3 +63,118 @@ func (s *TeamService) GetTeam(ctx context.Context, name domain.TeamName) (domain
 	}
 	return team, nil
 }
+
+func (s *TeamService) SetReviewerStrategy(ctx context.Context, name domain.TeamName, strategy domain.ReviewerStrategy) (domain.Team, error) {
+	if name == "" {
+		return domain.Team{}, domain.NewValidationError("team_name", "must not be empty")
+	}
+	if err := strategy.Validate(); err != nil {
+		return domain.Team{}, err
+	}
+
+	if err := s.teams.SetReviewerStrategy(ctx, name, strategy); err != nil {
+		s.logger.Error("set team reviewer strategy", slog.String("team", string(name)), slog.String("strategy", string(strategy)), slog.Any("err", err))
+		return domain.Team{}, err
+	}
+
+	return s.GetTeam(ctx, name)
+}
+
+func (s *TeamService) GetSettings(ctx context.Context, name domain.TeamName) (domain.TeamSettings, error) {
+	settings, err := s.teams.GetSettings(ctx, name)
+	if err != nil {
+		s.logger.Error("get team settings", slog.String("team", string(name)), slog.Any("err", err))
+		return domain.TeamSettings{}, err
+	}
+	return settings, nil
+}
+
+func (s *TeamService) UpdateSettings(ctx context.Context, name domain.TeamName, patch domain.TeamSettingsPatch) (domain.TeamSettings, error) {
+	if name == "" {
+		return domain.TeamSettings{}, domain.NewValidationError("team_name", "must not be empty")
+	}
+
+	current, err := s.GetSettings(ctx, name)
+	if err != nil {
+		return domain.TeamSettings{}, err
+	}
+
+	settings := current.Apply(patch)
+	if err := settings.Validate(); err != nil {
+		return domain.TeamSettings{}, err
+	}
+
+	if err := s.teams.UpsertSettings(ctx, settings); err != nil {
+		s.logger.Error("update team settings", slog.String("team", string(name)), slog.Any("err", err))
+		return domain.TeamSettings{}, err
+	}
+
+	return settings, nil
+}
+
+func (s *TeamService) GetFallbackTeams(ctx context.Context, team domain.TeamName) ([]domain.TeamName, error) {
+	if team == "" {
+		return nil, domain.NewValidationError("team_name", "must not be empty")
+	}
+
+	if _, err := s.teams.GetTeamByName(ctx, team); err != nil {
+		return nil, err
+	}
+
+	teams, err := s.teams.GetFallbackTeams(ctx, team)
+	if err != nil {
+		s.logger.Error("get fallback teams", slog.String("team", string(team)), slog.Any("err", err))
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (s *TeamService) SetFallbackTeams(ctx context.Context, team domain.TeamName, fallbacks []domain.TeamName) ([]domain.TeamName, error) {
+	if err := domain.ValidateFallbackTeams(team, fallbacks); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.teams.GetTeamByName(ctx, team); err != nil {
+		return nil, err
+	}
+
+	if err := s.teams.SetFallbackTeams(ctx, team, fallbacks); err != nil {
+		s.logger.Error("set fallback teams", slog.String("team", string(team)), slog.Any("err", err))
+		return nil, err
+	}
+	return fallbacks, nil
+}
+
+func (s *TeamService) GetCodeowners(ctx context.Context, name domain.TeamName) (domain.Codeowners, error) {
+	if name == "" {
+		return domain.Codeowners{}, domain.NewValidationError("team_name", "must not be empty")
+	}
+
+	co, err := s.teams.GetCodeowners(ctx, name)
+	if err != nil {
+		s.logger.Error("get team codeowners", slog.String("team", string(name)), slog.Any("err", err))
+		return domain.Codeowners{}, err
+	}
+	return co, nil
+}
+
+// SetCodeowners replaces the team's CODEOWNERS ruleset. An empty mode keeps
+// PREFER; the content must parse.
+func (s *TeamService) SetCodeowners(ctx context.Context, co domain.Codeowners) (domain.Codeowners, error) {
+	if co.Mode == "" {
+		co.Mode = domain.CodeownersModePrefer
+	}
+	if err := co.Validate(); err != nil {
+		return domain.Codeowners{}, err
+	}
+	if _, err := codeowners.Parse(co.Content); err != nil {
+		return domain.Codeowners{}, domain.NewValidationError("content", err.Error())
+	}
+
+	saved, err := s.teams.SetCodeowners(ctx, co)
+	if err != nil {
+		s.logger.Error("set team codeowners", slog.String("team", string(co.TeamName)), slog.Any("err", err))
+		return domain.Codeowners{}, err
+	}
+	return saved, nil
+}
@@ -30,6 +30,73 @@ func (s *UserService) SetIsActive(ctx context.Context, id domain.UserID, isActiv
 	return user, nil
 }
 
+func (s *UserService) SetSlackHandle(ctx context.Context, id domain.UserID, handle string) (domain.User, error) {
+	if id == "" {
+		return domain.User{}, domain.NewValidationError("user_id", "must not be empty")
+	}
+
+	user, err := s.users.SetSlackHandle(ctx, id, handle)
+	if err != nil {
+		s.logger.Error("set user slack handle", slog.String("user_id", string(id)), slog.Any("err", err))
+		return domain.User{}, err
+	}
+	return user, nil
+}
+
+func (s *UserService) SetEmail(ctx context.Context, id domain.UserID, email string) (domain.User, error) {
+	if id == "" {
+		return domain.User{}, domain.NewValidationError("user_id", "must not be empty")
+	}
+	if email != "" {
+		if err := domain.ValidateEmail(email); err != nil {
+			return domain.User{}, err
+		}
+	}
+
+	user, err := s.users.SetEmail(ctx, id, email)
+	if err != nil {
+		s.logger.Error("set user email", slog.String("user_id", string(id)), slog.Any("err", err))
+		return domain.User{}, err
+	}
+	return user, nil
+}
+
+// SetMaxOpenReviews sets the user's own review capacity. A nil limit makes
+// the user follow the team default again; zero exempts them from it.
+func (s *UserService) SetMaxOpenReviews(ctx context.Context, id domain.UserID, limit *int) (domain.User, error) {
+	if id == "" {
+		return domain.User{}, domain.NewValidationError("user_id", "must not be empty")
+	}
+	if limit != nil && *limit < 0 {
+		return domain.User{}, domain.NewValidationError("max_open_reviews", "must not be negative")
+	}
+
+	user, err := s.users.SetMaxOpenReviews(ctx, id, limit)
+	if err != nil {
+		s.logger.Error("set user max open reviews", slog.String("user_id", string(id)), slog.Any("err", err))
+		return domain.User{}, err
+	}
+	return user, nil
+}
+
+func (s *UserService) SetSkills(ctx context.Context, id domain.UserID, skills []string) (domain.User, error) {
+	if id == "" {
+		return domain.User{}, domain.NewValidationError("user_id", "must not be empty")
+	}
+
+	skills = domain.NormalizeTags(skills)
+	if err := domain.ValidateTags("skills", skills); err != nil {
+		return domain.User{}, err
+	}
+
+	user, err := s.users.SetSkills(ctx, id, skills)
+	if err != nil {
+		s.logger.Error("set user skills", slog.String("user_id", string(id)), slog.Any("err", err))
+		return domain.User{}, err
+	}
+	return user, nil
+}
+
 func (s *UserService) ListReviewPRs(ctx context.Context, id domain.UserID) (domain.User, []domain.PullRequest, error) {
 	user, err := s.users.GetUserByID(ctx, id)
 	if err != nil {
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+const (
+	deliveryListLimit = 100
+	deliveryBatchSize = 20
+	deliveryLease     = time.Minute
+)
+
+type WebhookService struct {
+	logger   *slog.Logger
+	webhooks WebhookRepository
+	sender   WebhookSender
+}
+
+func NewWebhookService(logger *slog.Logger, webhooks WebhookRepository, sender WebhookSender) *WebhookService {
+	return &WebhookService{
+		logger:   logger,
+		webhooks: webhooks,
+		sender:   sender,
+	}
+}
+
+func (s *WebhookService) CreateSubscription(ctx context.Context, sub domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	if err := sub.Validate(); err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+
+	created, err := s.webhooks.CreateSubscription(ctx, sub)
+	if err != nil {
+		s.logger.Error("create webhook subscription", slog.String("url", sub.URL), slog.Any("err", err))
+		return domain.WebhookSubscription{}, err
+	}
+	return created, nil
+}
+
+func (s *WebhookService) GetSubscription(ctx context.Context, id int64) (domain.WebhookSubscription, error) {
+	return s.webhooks.GetSubscription(ctx, id)
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return s.webhooks.ListSubscriptions(ctx)
+}
+
+func (s *WebhookService) UpdateSubscription(ctx context.Context, id int64, patch domain.WebhookSubscriptionPatch) (domain.WebhookSubscription, error) {
+	current, err := s.webhooks.GetSubscription(ctx, id)
+	if err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+
+	updated := current.Apply(patch)
+	if err := updated.Validate(); err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+
+	saved, err := s.webhooks.UpdateSubscription(ctx, updated)
+	if err != nil {
+		s.logger.Error("update webhook subscription", slog.Int64("subscription_id", id), slog.Any("err", err))
+		return domain.WebhookSubscription{}, err
+	}
+	return saved, nil
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id int64) error {
+	if err := s.webhooks.DeleteSubscription(ctx, id); err != nil {
+		s.logger.Error("delete webhook subscription", slog.Int64("subscription_id", id), slog.Any("err", err))
+		return err
+	}
+	return nil
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, subscriptionID int64, status domain.WebhookDeliveryStatus) ([]domain.WebhookDelivery, error) {
+	if status != "" {
+		if err := status.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := s.webhooks.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	return s.webhooks.ListDeliveries(ctx, subscriptionID, status, deliveryListLimit)
+}
+
+// Redeliver schedules the delivery for an immediate attempt with a fresh
+// retry budget, whatever its current status.
+func (s *WebhookService) Redeliver(ctx context.Context, id int64) (domain.WebhookDelivery, error) {
+	d, err := s.webhooks.Redeliver(ctx, id)
+	if err != nil {
+		s.logger.Error("redeliver webhook", slog.Int64("delivery_id", id), slog.Any("err", err))
+		return domain.WebhookDelivery{}, err
+	}
+	return d, nil
+}
+
+// Run delivers due webhooks every interval until ctx is cancelled.
+func (s *WebhookService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				n, err := s.DeliverDue(ctx)
+				if err != nil {
+					s.logger.Error("deliver webhooks", slog.Any("err", err))
+				}
+				if err != nil || n < deliveryBatchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// DeliverDue makes one attempt for a batch of due deliveries and returns
+// how many were attempted.
+func (s *WebhookService) DeliverDue(ctx context.Context) (int, error) {
+	batch, err := s.webhooks.ClaimDueDeliveries(ctx, deliveryBatchSize, deliveryLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, d := range batch {
+		s.deliver(ctx, d)
+	}
+
+	return len(batch), nil
+}
+
+func (s *WebhookService) deliver(ctx context.Context, d domain.WebhookDispatch) {
+	id := d.Delivery.ID
+
+	status, sendErr := s.sender.Send(ctx, d)
+	if sendErr == nil {
+		if err := s.webhooks.MarkDelivered(ctx, id, status); err != nil {
+			s.logger.Error("mark webhook delivered", slog.Int64("delivery_id", id), slog.Any("err", err))
+		}
+		return
+	}
+
+	var retryAt *time.Time
+	if d.Delivery.Attempts < domain.MaxWebhookAttempts {
+		t := time.Now().Add(domain.WebhookBackoff(d.Delivery.Attempts))
+		retryAt = &t
+	}
+
+	s.logger.Warn("webhook delivery failed",
+		slog.Int64("delivery_id", id),
+		slog.Int64("subscription_id", d.Delivery.SubscriptionID),
+		slog.Int("attempt", d.Delivery.Attempts),
+		slog.Bool("will_retry", retryAt != nil),
+		slog.Any("err", sendErr),
+	)
+
+	if err := s.webhooks.MarkFailed(ctx, id, status, sendErr.Error(), retryAt); err != nil {
+		s.logger.Error("mark webhook failed", slog.Int64("delivery_id", id), slog.Any("err", err))
+	}
+}
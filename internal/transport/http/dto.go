@@ -7,30 +7,40 @@ import (
 )
 
 type teamMemberDTO struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID         string   `json:"user_id"`
+	Username       string   `json:"username"`
+	IsActive       bool     `json:"is_active"`
+	SlackHandle    string   `json:"slack_handle,omitempty"`
+	Email          string   `json:"email,omitempty"`
+	MaxOpenReviews *int     `json:"max_open_reviews,omitempty"`
+	Skills         []string `json:"skills,omitempty"`
 }
 
 type teamDTO struct {
-	TeamName string          `json:"team_name"`
-	Members  []teamMemberDTO `json:"members"`
+	TeamName         string          `json:"team_name"`
+	Members          []teamMemberDTO `json:"members"`
+	ReviewerStrategy string          `json:"reviewer_strategy,omitempty"`
 }
 
 func teamFromDTO(dto teamDTO) domain.Team {
 	members := make([]domain.User, 0, len(dto.Members))
 	for _, m := range dto.Members {
 		members = append(members, domain.User{
-			ID:       domain.UserID(m.UserID),
-			Username: m.Username,
-			TeamName: domain.TeamName(dto.TeamName),
-			IsActive: m.IsActive,
+			ID:             domain.UserID(m.UserID),
+			Username:       m.Username,
+			TeamName:       domain.TeamName(dto.TeamName),
+			IsActive:       m.IsActive,
+			SlackHandle:    m.SlackHandle,
+			Email:          m.Email,
+			MaxOpenReviews: m.MaxOpenReviews,
+			Skills:         m.Skills,
 		})
 	}
 
 	return domain.Team{
-		Name:    domain.TeamName(dto.TeamName),
-		Members: members,
+		Name:             domain.TeamName(dto.TeamName),
+		Members:          members,
+		ReviewerStrategy: domain.ReviewerStrategy(dto.ReviewerStrategy),
 	}
 }
 
@@ -38,42 +48,138 @@ func teamToDTO(t domain.Team) teamDTO {
 	members := make([]teamMemberDTO, 0, len(t.Members))
 	for _, m := range t.Members {
 		members = append(members, teamMemberDTO{
-			UserID:   string(m.ID),
-			Username: m.Username,
-			IsActive: m.IsActive,
+			UserID:         string(m.ID),
+			Username:       m.Username,
+			IsActive:       m.IsActive,
+			SlackHandle:    m.SlackHandle,
+			Email:          m.Email,
+			MaxOpenReviews: m.MaxOpenReviews,
+			Skills:         m.Skills,
 		})
 	}
 
 	return teamDTO{
-		TeamName: string(t.Name),
-		Members:  members,
+		TeamName:         string(t.Name),
+		Members:          members,
+		ReviewerStrategy: string(t.ReviewerStrategy),
 	}
 }
 
+type teamSettingsDTO struct {
+	TeamName                string `json:"team_name"`
+	MinReviewers            int    `json:"min_reviewers"`
+	MaxReviewers            int    `json:"max_reviewers"`
+	RequiredApprovals       int    `json:"required_approvals"`
+	BlockOnChangesRequested bool   `json:"block_on_changes_requested"`
+	RequireAllApproved      bool   `json:"require_all_approved"`
+	SlackWebhookURL         string `json:"slack_webhook_url,omitempty"`
+	ReminderAfterHours      int    `json:"reminder_after_hours"`
+	EscalateAfterHours      int    `json:"escalate_after_hours"`
+	MaxOpenReviews          int    `json:"max_open_reviews"`
+}
+
+type teamSettingsPatchDTO struct {
+	TeamName                string  `json:"team_name"`
+	MinReviewers            *int    `json:"min_reviewers"`
+	MaxReviewers            *int    `json:"max_reviewers"`
+	RequiredApprovals       *int    `json:"required_approvals"`
+	BlockOnChangesRequested *bool   `json:"block_on_changes_requested"`
+	RequireAllApproved      *bool   `json:"require_all_approved"`
+	SlackWebhookURL         *string `json:"slack_webhook_url"`
+	ReminderAfterHours      *int    `json:"reminder_after_hours"`
+	EscalateAfterHours      *int    `json:"escalate_after_hours"`
+	MaxOpenReviews          *int    `json:"max_open_reviews"`
+}
+
+func teamSettingsPatchFromDTO(dto teamSettingsPatchDTO) domain.TeamSettingsPatch {
+	return domain.TeamSettingsPatch{
+		MinReviewers:            dto.MinReviewers,
+		MaxReviewers:            dto.MaxReviewers,
+		RequiredApprovals:       dto.RequiredApprovals,
+		BlockOnChangesRequested: dto.BlockOnChangesRequested,
+		RequireAllApproved:      dto.RequireAllApproved,
+		SlackWebhookURL:         dto.SlackWebhookURL,
+		ReminderAfterHours:      dto.ReminderAfterHours,
+		EscalateAfterHours:      dto.EscalateAfterHours,
+		MaxOpenReviews:          dto.MaxOpenReviews,
+	}
+}
+
+func teamSettingsToDTO(s domain.TeamSettings) teamSettingsDTO {
+	return teamSettingsDTO{
+		TeamName:                string(s.TeamName),
+		MinReviewers:            s.MinReviewers,
+		MaxReviewers:            s.MaxReviewers,
+		RequiredApprovals:       s.RequiredApprovals,
+		BlockOnChangesRequested: s.BlockOnChangesRequested,
+		RequireAllApproved:      s.RequireAllApproved,
+		SlackWebhookURL:         s.SlackWebhookURL,
+		ReminderAfterHours:      s.ReminderAfterHours,
+		EscalateAfterHours:      s.EscalateAfterHours,
+		MaxOpenReviews:          s.MaxOpenReviews,
+	}
+}
+
+type mergeBlockerDTO struct {
+	Condition string   `json:"condition"`
+	Required  *int     `json:"required,omitempty"`
+	Actual    *int     `json:"actual,omitempty"`
+	Reviewers []string `json:"reviewers,omitempty"`
+}
+
+func mergeBlockerToDTO(b domain.MergeBlocker) mergeBlockerDTO {
+	dto := mergeBlockerDTO{Condition: string(b.Condition)}
+	if b.Condition != domain.MergeConditionChangesRequested {
+		dto.Required = &b.Required
+		dto.Actual = &b.Actual
+	}
+	for _, id := range b.Reviewers {
+		dto.Reviewers = append(dto.Reviewers, string(id))
+	}
+	return dto
+}
+
 type userDTO struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID         string   `json:"user_id"`
+	Username       string   `json:"username"`
+	TeamName       string   `json:"team_name"`
+	IsActive       bool     `json:"is_active"`
+	SlackHandle    string   `json:"slack_handle,omitempty"`
+	Email          string   `json:"email,omitempty"`
+	MaxOpenReviews *int     `json:"max_open_reviews,omitempty"`
+	Skills         []string `json:"skills,omitempty"`
 }
 
 func userToDTO(u domain.User) userDTO {
 	return userDTO{
-		UserID:   string(u.ID),
-		Username: u.Username,
-		TeamName: string(u.TeamName),
-		IsActive: u.IsActive,
+		UserID:         string(u.ID),
+		Username:       u.Username,
+		TeamName:       string(u.TeamName),
+		IsActive:       u.IsActive,
+		SlackHandle:    u.SlackHandle,
+		Email:          u.Email,
+		MaxOpenReviews: u.MaxOpenReviews,
+		Skills:         u.Skills,
 	}
 }
 
+type reviewDTO struct {
+	UserID     string     `json:"user_id"`
+	State      string     `json:"state"`
+	ReviewedAt *time.Time `json:"reviewedAt,omitempty"`
+}
+
 type pullRequestDTO struct {
-	PullRequestID     string     `json:"pull_request_id"`
-	PullRequestName   string     `json:"pull_request_name"`
-	AuthorID          string     `json:"author_id"`
-	Status            string     `json:"status"`
-	AssignedReviewers []string   `json:"assigned_reviewers"`
-	CreatedAt         *time.Time `json:"createdAt,omitempty"`
-	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+	PullRequestID     string      `json:"pull_request_id"`
+	PullRequestName   string      `json:"pull_request_name"`
+	AuthorID          string      `json:"author_id"`
+	Status            string      `json:"status"`
+	AssignedReviewers []string    `json:"assigned_reviewers"`
+	Reviews           []reviewDTO `json:"reviews"`
+	Labels            []string    `json:"labels,omitempty"`
+	CreatedAt         *time.Time  `json:"createdAt,omitempty"`
+	MergedAt          *time.Time  `json:"mergedAt,omitempty"`
+	ClosedAt          *time.Time  `json:"closedAt,omitempty"`
 }
 
 func prToDTO(pr domain.PullRequest) pullRequestDTO {
@@ -82,29 +188,117 @@ func prToDTO(pr domain.PullRequest) pullRequestDTO {
 		reviewers = append(reviewers, string(id))
 	}
 
+	reviews := make([]reviewDTO, 0, len(pr.Reviews))
+	for _, rv := range pr.Reviews {
+		reviews = append(reviews, reviewDTO{
+			UserID:     string(rv.ReviewerID),
+			State:      string(rv.State),
+			ReviewedAt: rv.ReviewedAt,
+		})
+	}
+
 	return pullRequestDTO{
 		PullRequestID:     string(pr.ID),
 		PullRequestName:   pr.Name,
 		AuthorID:          string(pr.AuthorID),
 		Status:            string(pr.Status),
 		AssignedReviewers: reviewers,
+		Reviews:           reviews,
+		Labels:            pr.Labels,
 		CreatedAt:         pr.CreatedAt,
 		MergedAt:          pr.MergedAt,
+		ClosedAt:          pr.ClosedAt,
 	}
 }
 
 type pullRequestShortDTO struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
-	Status          string `json:"status"`
+	PullRequestID   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+	AuthorID        string     `json:"author_id"`
+	Status          string     `json:"status"`
+	ReviewState     string     `json:"review_state,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewedAt,omitempty"`
 }
 
 func prToShortDTO(pr domain.PullRequest) pullRequestShortDTO {
-	return pullRequestShortDTO{
+	dto := pullRequestShortDTO{
 		PullRequestID:   string(pr.ID),
 		PullRequestName: pr.Name,
 		AuthorID:        string(pr.AuthorID),
 		Status:          string(pr.Status),
 	}
+
+	if len(pr.Reviews) == 1 {
+		dto.ReviewState = string(pr.Reviews[0].State)
+		dto.ReviewedAt = pr.Reviews[0].ReviewedAt
+	}
+
+	return dto
+}
+
+type backlogEntryDTO struct {
+	PullRequestID     string     `json:"pull_request_id"`
+	PullRequestName   string     `json:"pull_request_name"`
+	AuthorID          string     `json:"author_id"`
+	TeamName          string     `json:"team_name"`
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	Missing           int        `json:"missing"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	LastAttemptAt     *time.Time `json:"lastAttemptAt,omitempty"`
+}
+
+func backlogEntryToDTO(e domain.BacklogEntry) backlogEntryDTO {
+	reviewers := make([]string, 0, len(e.AssignedReviewers))
+	for _, id := range e.AssignedReviewers {
+		reviewers = append(reviewers, string(id))
+	}
+
+	return backlogEntryDTO{
+		PullRequestID:     string(e.PullRequestID),
+		PullRequestName:   e.Name,
+		AuthorID:          string(e.AuthorID),
+		TeamName:          string(e.TeamName),
+		AssignedReviewers: reviewers,
+		Missing:           e.Missing,
+		CreatedAt:         e.CreatedAt,
+		LastAttemptAt:     e.LastAttemptAt,
+	}
+}
+
+type assignmentDTO struct {
+	UserID       string     `json:"user_id"`
+	Reason       string     `json:"reason"`
+	Actor        string     `json:"actor,omitempty"`
+	AssignedAt   time.Time  `json:"assignedAt"`
+	UnassignedAt *time.Time `json:"unassignedAt,omitempty"`
+}
+
+func assignmentToDTO(a domain.ReviewAssignment) assignmentDTO {
+	return assignmentDTO{
+		UserID:       string(a.ReviewerID),
+		Reason:       string(a.Reason),
+		Actor:        string(a.Actor),
+		AssignedAt:   a.AssignedAt,
+		UnassignedAt: a.UnassignedAt,
+	}
+}
+
+type prEventDTO struct {
+	Type      string    `json:"type"`
+	Actor     string    `json:"actor,omitempty"`
+	UserID    string    `json:"user_id,omitempty"`
+	OldUserID string    `json:"old_user_id,omitempty"`
+	Details   string    `json:"details,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func prEventToDTO(e domain.PREvent) prEventDTO {
+	return prEventDTO{
+		Type:      string(e.Type),
+		Actor:     string(e.Actor),
+		UserID:    string(e.ReviewerID),
+		OldUserID: string(e.OldReviewerID),
+		Details:   e.Details,
+		CreatedAt: e.CreatedAt,
+	}
 }
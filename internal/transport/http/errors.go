@@ -18,6 +18,21 @@ func (h *Handler) writeError(w http.ResponseWriter, err error) {
 		})
 		return
 	}
+	var merr *domain.MergeBlockedError
+	if errors.As(err, &merr) {
+		blockers := make([]mergeBlockerDTO, 0, len(merr.Blockers))
+		for _, b := range merr.Blockers {
+			blockers = append(blockers, mergeBlockerToDTO(b))
+		}
+		h.writeJSON(w, httpStatusFromDomainCode(merr.Code), map[string]any{
+			"error": map[string]any{
+				"code":     string(merr.Code),
+				"message":  merr.Message,
+				"blockers": blockers,
+			},
+		})
+		return
+	}
 	var derr *domain.DomainError
 	if errors.As(err, &derr) {
 		status := httpStatusFromDomainCode(derr.Code)
@@ -43,10 +58,16 @@ func httpStatusFromDomainCode(code domain.ErrorCode) int {
 		return http.StatusBadRequest // 400
 	case domain.ErrPRExists:
 		return http.StatusConflict // 409
-	case domain.ErrPRMerged, domain.ErrNotAssigned, domain.ErrNoCandidate:
+	case domain.ErrPRMerged, domain.ErrNotAssigned, domain.ErrNoCandidate, domain.ErrMergeBlocked:
+		return http.StatusConflict // 409
+	case domain.ErrPRClosed, domain.ErrPRDraft:
 		return http.StatusConflict // 409
 	case domain.ErrNotFound:
 		return http.StatusNotFound // 404
+	case domain.ErrUnauthorized:
+		return http.StatusUnauthorized // 401
+	case domain.ErrTooLarge:
+		return http.StatusRequestEntityTooLarge // 413
 	default:
 		return http.StatusInternalServerError
 	}
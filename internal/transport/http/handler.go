@@ -14,15 +14,27 @@ type Handler struct {
 	userService  *service.UserService
 	prService    *service.PRService
 	statsService *service.StatsService
+
+	webhookService      *service.WebhookService
+	integrationService  *service.IntegrationService
+	absenceService      *service.AbsenceService
+	githubWebhookSecret string
+	gitlabWebhookToken  string
 }
 
-func NewHandler(logger *slog.Logger, teamService *service.TeamService, userService *service.UserService, prService *service.PRService, statsService *service.StatsService) *Handler {
+func NewHandler(logger *slog.Logger, teamService *service.TeamService, userService *service.UserService, prService *service.PRService, statsService *service.StatsService, webhookService *service.WebhookService, integrationService *service.IntegrationService, absenceService *service.AbsenceService, githubWebhookSecret, gitlabWebhookToken string) *Handler {
 	return &Handler{
 		logger:       logger,
 		teamService:  teamService,
 		userService:  userService,
 		prService:    prService,
 		statsService: statsService,
+
+		webhookService:      webhookService,
+		integrationService:  integrationService,
+		absenceService:      absenceService,
+		githubWebhookSecret: githubWebhookSecret,
+		gitlabWebhookToken:  gitlabWebhookToken,
 	}
 
 }
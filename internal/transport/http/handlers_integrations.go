@@ -0,0 +1,268 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+const maxWebhookBodySize = 1 << 20
+
+type userMappingDTO struct {
+	Login      string `json:"login"`
+	ExternalID int64  `json:"external_id,omitempty"`
+	UserID     string `json:"user_id"`
+}
+
+type userMappingsRequest struct {
+	Provider string           `json:"provider"`
+	Mappings []userMappingDTO `json:"mappings"`
+}
+
+type userMappingsResponse struct {
+	Provider string           `json:"provider"`
+	Mappings []userMappingDTO `json:"mappings"`
+}
+
+type webhookResponse struct {
+	Status string          `json:"status"`
+	PR     *pullRequestDTO `json:"pr,omitempty"`
+}
+
+type githubPullRequestEvent struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Title    string     `json:"title"`
+		Draft    bool       `json:"draft"`
+		Merged   bool       `json:"merged"`
+		MergedAt *time.Time `json:"merged_at"`
+		User     struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Sender struct {
+		Login string `json:"login"`
+	} `json:"sender"`
+}
+
+type gitlabMergeRequestEvent struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		ID       int64  `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID      int    `json:"iid"`
+		Title    string `json:"title"`
+		AuthorID int64  `json:"author_id"`
+		Action   string `json:"action"`
+		Draft    bool   `json:"draft"`
+	} `json:"object_attributes"`
+}
+
+var gitlabActions = map[string]domain.ExternalPRAction{
+	"open":   domain.ExternalPROpened,
+	"reopen": domain.ExternalPRReopened,
+	"close":  domain.ExternalPRClosed,
+	"merge":  domain.ExternalPRClosed,
+}
+
+func (h *Handler) SetUserMappings(w http.ResponseWriter, r *http.Request) {
+	var req userMappingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	mappings := make([]domain.ExternalUserMapping, 0, len(req.Mappings))
+	for _, m := range req.Mappings {
+		mappings = append(mappings, domain.ExternalUserMapping{
+			Provider:   domain.ExternalProvider(req.Provider),
+			Login:      m.Login,
+			ExternalID: m.ExternalID,
+			UserID:     domain.UserID(m.UserID),
+		})
+	}
+
+	if err := h.integrationService.SetUserMappings(r.Context(), mappings); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeUserMappings(w, r, domain.ExternalProvider(req.Provider))
+}
+
+func (h *Handler) GetUserMappings(w http.ResponseWriter, r *http.Request) {
+	h.writeUserMappings(w, r, domain.ExternalProvider(r.URL.Query().Get("provider")))
+}
+
+func (h *Handler) writeUserMappings(w http.ResponseWriter, r *http.Request, provider domain.ExternalProvider) {
+	mappings, err := h.integrationService.ListUserMappings(r.Context(), provider)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	resp := userMappingsResponse{
+		Provider: string(provider),
+		Mappings: make([]userMappingDTO, 0, len(mappings)),
+	}
+	for _, m := range mappings {
+		resp.Mappings = append(resp.Mappings, userMappingDTO{Login: m.Login, ExternalID: m.ExternalID, UserID: string(m.UserID)})
+	}
+
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// webhookBodyError is the error to answer a failed webhook body read with: a
+// body over maxWebhookBodySize is refused as too large rather than cut off.
+func webhookBodyError(err error, fallback error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return domain.NewDomainError(domain.ErrTooLarge, fmt.Sprintf("webhook payload exceeds %d bytes", tooLarge.Limit))
+	}
+	return fallback
+}
+
+func (h *Handler) GitHubWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		h.writeError(w, webhookBodyError(err, domain.NewValidationError("body", "cannot read body")))
+		return
+	}
+
+	if !verifyGitHubSignature(h.githubWebhookSecret, body, r.Header.Get("X-Hub-Signature-256")) {
+		h.writeError(w, domain.NewDomainError(domain.ErrUnauthorized, "invalid webhook signature"))
+		return
+	}
+
+	if r.Header.Get("X-GitHub-Event") != "pull_request" {
+		h.writeJSON(w, http.StatusOK, webhookResponse{Status: "ignored"})
+		return
+	}
+
+	var payload githubPullRequestEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	h.handlePREvent(w, r, domain.ExternalPREvent{
+		Provider:    domain.ProviderGitHub,
+		Action:      domain.ExternalPRAction(payload.Action),
+		Repository:  payload.Repository.FullName,
+		Number:      payload.Number,
+		Title:       payload.PullRequest.Title,
+		AuthorLogin: payload.PullRequest.User.Login,
+		ActorLogin:  payload.Sender.Login,
+		Draft:       payload.PullRequest.Draft,
+		Merged:      payload.PullRequest.Merged,
+		MergedAt:    payload.PullRequest.MergedAt,
+	})
+}
+
+func (h *Handler) GitLabWebhook(w http.ResponseWriter, r *http.Request) {
+	if !verifyGitLabToken(h.gitlabWebhookToken, r.Header.Get("X-Gitlab-Token")) {
+		h.writeError(w, domain.NewDomainError(domain.ErrUnauthorized, "invalid webhook token"))
+		return
+	}
+
+	if r.Header.Get("X-Gitlab-Event") != "Merge Request Hook" {
+		h.writeJSON(w, http.StatusOK, webhookResponse{Status: "ignored"})
+		return
+	}
+
+	var payload gitlabMergeRequestEvent
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWebhookBodySize)).Decode(&payload); err != nil {
+		h.writeError(w, webhookBodyError(err, domain.NewValidationError("body", "invalid JSON body")))
+		return
+	}
+
+	action, ok := gitlabActions[payload.ObjectAttributes.Action]
+	if !ok {
+		h.writeJSON(w, http.StatusOK, webhookResponse{Status: "ignored"})
+		return
+	}
+
+	// The hook carries only the numeric author id; "user" is whoever
+	// triggered the event and is the author only when the ids match.
+	ev := domain.ExternalPREvent{
+		Provider:         domain.ProviderGitLab,
+		Action:           action,
+		Repository:       payload.Project.PathWithNamespace,
+		Number:           payload.ObjectAttributes.IID,
+		Title:            payload.ObjectAttributes.Title,
+		AuthorExternalID: payload.ObjectAttributes.AuthorID,
+		ActorLogin:       payload.User.Username,
+		Draft:            payload.ObjectAttributes.Draft,
+		Merged:           payload.ObjectAttributes.Action == "merge",
+	}
+	if payload.User.ID != 0 && payload.User.ID == payload.ObjectAttributes.AuthorID {
+		ev.AuthorLogin = payload.User.Username
+	}
+	h.handlePREvent(w, r, ev)
+}
+
+func (h *Handler) handlePREvent(w http.ResponseWriter, r *http.Request, ev domain.ExternalPREvent) {
+	pr, handled, err := h.integrationService.HandlePREvent(r.Context(), ev)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	if !handled {
+		h.writeJSON(w, http.StatusOK, webhookResponse{Status: "ignored"})
+		return
+	}
+
+	dto := prToDTO(pr)
+	h.writeJSON(w, http.StatusOK, webhookResponse{Status: "processed", PR: &dto})
+}
+
+// verifyGitHubSignature checks the "sha256=<hex>" HMAC of the body.
+// An empty secret rejects every request.
+func verifyGitHubSignature(secret string, body []byte, header string) bool {
+	if secret == "" {
+		return false
+	}
+
+	sig, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// verifyGitLabToken compares the shared secret GitLab sends as is.
+// An empty token rejects every request.
+func verifyGitLabToken(token, header string) bool {
+	if token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(header)) == 1
+}
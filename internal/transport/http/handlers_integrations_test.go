@@ -0,0 +1,72 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVerifyGitHubSignature(t *testing.T) {
+	const secret = "It's a Secret to Everybody"
+	body := []byte("Hello, World!")
+
+	// Example from the GitHub webhook documentation.
+	const valid = "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+
+	tests := []struct {
+		name   string
+		secret string
+		header string
+		want   bool
+	}{
+		{"valid", secret, valid, true},
+		{"wrong digest", secret, "sha256=0000000000000000000000000000000000000000000000000000000000000000", false},
+		{"wrong secret", "other", valid, false},
+		{"not hex", secret, "sha256=zz", false},
+		{"missing prefix", secret, valid[len("sha256="):], false},
+		{"missing header", secret, "", false},
+		{"empty secret", "", valid, false},
+	}
+
+	for _, tt := range tests {
+		if got := verifyGitHubSignature(tt.secret, body, tt.header); got != tt.want {
+			t.Errorf("%s: verifyGitHubSignature = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWebhooksRefuseOversizedPayloads(t *testing.T) {
+	h := &Handler{
+		logger:              slog.New(slog.NewTextHandler(io.Discard, nil)),
+		githubWebhookSecret: "secret",
+		gitlabWebhookToken:  "token",
+	}
+	// Valid JSON as far as it goes, so only the size can stop it.
+	body := append([]byte(`{"object_attributes": {"title": "`), bytes.Repeat([]byte("x"), maxWebhookBodySize)...)
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		header  string
+		value   string
+	}{
+		{"github", h.GitHubWebhook, "X-Hub-Signature-256", "sha256=00"},
+		{"gitlab", h.GitLabWebhook, "X-Gitlab-Token", "token"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+		req.Header.Set(tt.header, tt.value)
+		req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+		rec := httptest.NewRecorder()
+
+		tt.handler(rec, req)
+
+		if rec.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, http.StatusRequestEntityTooLarge)
+		}
+	}
+}
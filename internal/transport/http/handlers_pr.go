@@ -1,34 +1,157 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 
 	"github.com/freeholder/pr-reviewer-service/internal/domain"
+	"github.com/freeholder/pr-reviewer-service/internal/service"
 )
 
 type createPRRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	PullRequestID   string   `json:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name"`
+	AuthorID        string   `json:"author_id"`
+	Draft           bool     `json:"draft"`
+	ChangedFiles    []string `json:"changed_files"`
+	Labels          []string `json:"labels"`
 }
 
 type mergePRRequest struct {
 	PullRequestID string `json:"pull_request_id"`
+	ActorID       string `json:"actor_id"`
+}
+
+type prIDRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	ActorID       string `json:"actor_id"`
 }
 
 type reassignRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 	OldUserID     string `json:"old_user_id"`
+	NewUserID     string `json:"new_user_id"`
+	ActorID       string `json:"actor_id"`
+}
+
+type reviewRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+	State         string `json:"state"`
+}
+
+type assignmentsResponse struct {
+	PullRequestID string          `json:"pull_request_id"`
+	Assignments   []assignmentDTO `json:"assignments"`
+}
+
+type historyResponse struct {
+	PullRequestID string       `json:"pull_request_id"`
+	Events        []prEventDTO `json:"events"`
 }
 
 type prResponse struct {
 	PR pullRequestDTO `json:"pr"`
 }
 
+type assignmentReportDTO struct {
+	Requested  int                      `json:"requested"`
+	Assigned   int                      `json:"assigned"`
+	Unfilled   int                      `json:"unfilled"`
+	AtCapacity []string                 `json:"at_capacity,omitempty"`
+	CodeOwners []codeOwnerAssignmentDTO `json:"code_owners,omitempty"`
+	Fallbacks  []fallbackAssignmentDTO  `json:"fallbacks,omitempty"`
+	LabelMatch *labelMatchDTO           `json:"label_match,omitempty"`
+}
+
+type labelMatchDTO struct {
+	Labels    []string        `json:"labels"`
+	Matched   []skillMatchDTO `json:"matched"`
+	Rationale string          `json:"rationale"`
+}
+
+type skillMatchDTO struct {
+	UserID string   `json:"user_id"`
+	Skills []string `json:"skills"`
+}
+
+type codeOwnerAssignmentDTO struct {
+	UserID       string   `json:"user_id"`
+	Paths        []string `json:"paths"`
+	FallbackTeam string   `json:"fallback_team,omitempty"`
+}
+
+type fallbackAssignmentDTO struct {
+	TeamName  string   `json:"team_name"`
+	Reviewers []string `json:"reviewers"`
+}
+
+type createPRResponse struct {
+	PR         pullRequestDTO       `json:"pr"`
+	Assignment *assignmentReportDTO `json:"assignment,omitempty"`
+}
+
+func assignmentReportToDTO(r service.AssignmentReport) *assignmentReportDTO {
+	dto := &assignmentReportDTO{
+		Requested: r.Requested,
+		Assigned:  len(r.Reviewers),
+		Unfilled:  r.Unfilled(),
+	}
+	for _, id := range r.AtCapacity {
+		dto.AtCapacity = append(dto.AtCapacity, string(id))
+	}
+	for _, o := range r.CodeOwners {
+		dto.CodeOwners = append(dto.CodeOwners, codeOwnerAssignmentDTO{UserID: string(o.ReviewerID), Paths: o.Paths, FallbackTeam: string(o.FallbackTeam)})
+	}
+	if m := r.LabelMatch; m != nil {
+		dto.LabelMatch = &labelMatchDTO{
+			Labels:    m.Labels,
+			Matched:   make([]skillMatchDTO, 0, len(m.Matched)),
+			Rationale: m.Rationale,
+		}
+		for _, sm := range m.Matched {
+			dto.LabelMatch.Matched = append(dto.LabelMatch.Matched, skillMatchDTO{UserID: string(sm.ReviewerID), Skills: sm.Skills})
+		}
+	}
+	for _, f := range r.Fallbacks {
+		fb := fallbackAssignmentDTO{TeamName: string(f.TeamName)}
+		for _, id := range f.Reviewers {
+			fb.Reviewers = append(fb.Reviewers, string(id))
+		}
+		dto.Fallbacks = append(dto.Fallbacks, fb)
+	}
+	return dto
+}
+
+type suggestRequest struct {
+	PullRequestID string   `json:"pull_request_id"`
+	AuthorID      string   `json:"author_id"`
+	ChangedFiles  []string `json:"changed_files"`
+	Labels        []string `json:"labels"`
+}
+
+type suggestedCandidateDTO struct {
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	TeamName    string `json:"team_name"`
+	Rank        int    `json:"rank,omitempty"`
+	Selected    bool   `json:"selected"`
+	Reason      string `json:"reason"`
+	OpenReviews int    `json:"open_reviews"`
+}
+
+type suggestResponse struct {
+	TeamName   string                  `json:"team_name"`
+	Assignment *assignmentReportDTO    `json:"assignment"`
+	Blocker    string                  `json:"blocker,omitempty"`
+	Candidates []suggestedCandidateDTO `json:"candidates"`
+}
+
 type reassignResponse struct {
-	PR         pullRequestDTO `json:"pr"`
-	ReplacedBy string         `json:"replaced_by"`
+	PR           pullRequestDTO `json:"pr"`
+	ReplacedBy   string         `json:"replaced_by"`
+	FallbackTeam string         `json:"fallback_team,omitempty"`
 }
 
 func (h *Handler) PRCreate(w http.ResponseWriter, r *http.Request) {
@@ -38,13 +161,64 @@ func (h *Handler) PRCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, err := h.prService.Create(r.Context(), domain.PullRequestID(req.PullRequestID), req.PullRequestName, domain.UserID(req.AuthorID))
+	pr, report, err := h.prService.Create(r.Context(), service.CreatePRInput{
+		ID:           domain.PullRequestID(req.PullRequestID),
+		Name:         req.PullRequestName,
+		AuthorID:     domain.UserID(req.AuthorID),
+		Draft:        req.Draft,
+		ChangedFiles: req.ChangedFiles,
+		Labels:       req.Labels,
+	})
 	if err != nil {
 		h.writeError(w, err)
 		return
 	}
 
-	h.writeJSON(w, http.StatusCreated, prResponse{PR: prToDTO(pr)})
+	resp := createPRResponse{PR: prToDTO(pr)}
+	if !req.Draft {
+		resp.Assignment = assignmentReportToDTO(report)
+	}
+
+	h.writeJSON(w, http.StatusCreated, resp)
+}
+
+func (h *Handler) PRSuggest(w http.ResponseWriter, r *http.Request) {
+	var req suggestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	res, err := h.prService.Suggest(r.Context(), service.SuggestInput{
+		PullRequestID: domain.PullRequestID(req.PullRequestID),
+		AuthorID:      domain.UserID(req.AuthorID),
+		ChangedFiles:  req.ChangedFiles,
+		Labels:        req.Labels,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	resp := suggestResponse{
+		TeamName:   string(res.TeamName),
+		Assignment: assignmentReportToDTO(res.Report),
+		Blocker:    res.Blocker,
+		Candidates: make([]suggestedCandidateDTO, 0, len(res.Candidates)),
+	}
+	for _, c := range res.Candidates {
+		resp.Candidates = append(resp.Candidates, suggestedCandidateDTO{
+			UserID:      string(c.UserID),
+			Username:    c.Username,
+			TeamName:    string(c.TeamName),
+			Rank:        c.Rank,
+			Selected:    c.Selected,
+			Reason:      string(c.Reason),
+			OpenReviews: c.OpenReviews,
+		})
+	}
+
+	h.writeJSON(w, http.StatusOK, resp)
 }
 
 func (h *Handler) PRMerge(w http.ResponseWriter, r *http.Request) {
@@ -54,7 +228,74 @@ func (h *Handler) PRMerge(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, err := h.prService.Merge(r.Context(), domain.PullRequestID(req.PullRequestID))
+	pr, err := h.prService.Merge(r.Context(), domain.PullRequestID(req.PullRequestID), domain.UserID(req.ActorID))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, prResponse{PR: prToDTO(pr)})
+}
+
+func (h *Handler) PRClose(w http.ResponseWriter, r *http.Request) {
+	h.prTransition(w, r, h.prService.Close)
+}
+
+func (h *Handler) PRReopen(w http.ResponseWriter, r *http.Request) {
+	h.prOpen(w, r, h.prService.Reopen)
+}
+
+func (h *Handler) PRReady(w http.ResponseWriter, r *http.Request) {
+	h.prOpen(w, r, h.prService.MarkReady)
+}
+
+// prOpen runs a transition to OPEN and adds the assignment report when
+// reviewers were picked on the way, as for a new PR.
+func (h *Handler) prOpen(w http.ResponseWriter, r *http.Request, transition func(context.Context, domain.PullRequestID, domain.UserID) (domain.PullRequest, *service.AssignmentReport, error)) {
+	var req prIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	pr, report, err := transition(r.Context(), domain.PullRequestID(req.PullRequestID), domain.UserID(req.ActorID))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	resp := createPRResponse{PR: prToDTO(pr)}
+	if report != nil {
+		resp.Assignment = assignmentReportToDTO(*report)
+	}
+
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) prTransition(w http.ResponseWriter, r *http.Request, transition func(context.Context, domain.PullRequestID, domain.UserID) (domain.PullRequest, error)) {
+	var req prIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	pr, err := transition(r.Context(), domain.PullRequestID(req.PullRequestID), domain.UserID(req.ActorID))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, prResponse{PR: prToDTO(pr)})
+}
+
+func (h *Handler) PRReview(w http.ResponseWriter, r *http.Request) {
+	var req reviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	pr, err := h.prService.SubmitReview(r.Context(), domain.PullRequestID(req.PullRequestID), domain.UserID(req.UserID), domain.ReviewState(req.State))
 	if err != nil {
 		h.writeError(w, err)
 		return
@@ -70,19 +311,88 @@ func (h *Handler) PRReassign(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, replacedBy, err := h.prService.ReassignReviewer(
-		r.Context(),
-		domain.PullRequestID(req.PullRequestID),
-		domain.UserID(req.OldUserID),
-	)
+	res, err := h.prService.ReassignReviewer(r.Context(), service.ReassignInput{
+		PullRequestID: domain.PullRequestID(req.PullRequestID),
+		OldReviewerID: domain.UserID(req.OldUserID),
+		NewReviewerID: domain.UserID(req.NewUserID),
+		Actor:         domain.UserID(req.ActorID),
+	})
 	if err != nil {
 		h.writeError(w, err)
 		return
 	}
 
 	resp := reassignResponse{
-		PR:         prToDTO(pr),
-		ReplacedBy: string(replacedBy),
+		PR:           prToDTO(res.PR),
+		ReplacedBy:   string(res.NewReviewerID),
+		FallbackTeam: string(res.FallbackTeam),
+	}
+
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) PRAssignments(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		h.writeError(w, domain.NewValidationError("pull_request_id", "must not be empty"))
+		return
+	}
+
+	assignments, err := h.prService.ListAssignments(r.Context(), domain.PullRequestID(prID))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	resp := assignmentsResponse{
+		PullRequestID: prID,
+		Assignments:   make([]assignmentDTO, 0, len(assignments)),
+	}
+	for _, a := range assignments {
+		resp.Assignments = append(resp.Assignments, assignmentToDTO(a))
+	}
+
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) PRBacklog(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.prService.ListBacklog(r.Context(), domain.TeamName(r.URL.Query().Get("team_name")))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	resp := struct {
+		PullRequests []backlogEntryDTO `json:"pull_requests"`
+	}{
+		PullRequests: make([]backlogEntryDTO, 0, len(entries)),
+	}
+	for _, e := range entries {
+		resp.PullRequests = append(resp.PullRequests, backlogEntryToDTO(e))
+	}
+
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) PRHistory(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		h.writeError(w, domain.NewValidationError("pull_request_id", "must not be empty"))
+		return
+	}
+
+	events, err := h.prService.History(r.Context(), domain.PullRequestID(prID))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	resp := historyResponse{
+		PullRequestID: prID,
+		Events:        make([]prEventDTO, 0, len(events)),
+	}
+	for _, e := range events {
+		resp.Events = append(resp.Events, prEventToDTO(e))
 	}
 
 	h.writeJSON(w, http.StatusOK, resp)
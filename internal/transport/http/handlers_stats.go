@@ -1,17 +1,39 @@
 package http
 
-import "net/http"
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
 
 type reviewerStatDTO struct {
 	UserID        string `json:"user_id"`
 	Username      string `json:"username"`
 	AssignedCount int64  `json:"assigned_count"`
+	OpenCount     int64  `json:"open_count"`
 }
 
 func (h *Handler) GetReviewerStats(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	q := r.URL.Query()
+
+	from, to, err := parseTimeWindow(q)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	filter := domain.ReviewerStatsFilter{
+		From:     from,
+		To:       to,
+		TeamName: domain.TeamName(q.Get("team_name")),
+		Status:   domain.PRStatus(strings.ToUpper(q.Get("status"))),
+	}
 
-	stats, err := h.statsService.GetReviewerStats(ctx)
+	stats, err := h.statsService.GetReviewerStats(ctx, filter)
 	if err != nil {
 		h.writeError(w, err)
 		return
@@ -28,8 +50,174 @@ func (h *Handler) GetReviewerStats(w http.ResponseWriter, r *http.Request) {
 			UserID:        string(s.UserID),
 			Username:      s.Username,
 			AssignedCount: s.AssignedCount,
+			OpenCount:     s.OpenCount,
 		})
 	}
 
 	h.writeJSON(w, http.StatusOK, resp)
 }
+
+type latencyPercentilesDTO struct {
+	Count      int64   `json:"count"`
+	P50Seconds float64 `json:"p50_seconds"`
+	P90Seconds float64 `json:"p90_seconds"`
+}
+
+type teamLatencyDTO struct {
+	TeamName          string                `json:"team_name"`
+	TimeToFirstReview latencyPercentilesDTO `json:"time_to_first_review"`
+	TimeToMerge       latencyPercentilesDTO `json:"time_to_merge"`
+}
+
+type reviewerLatencyDTO struct {
+	UserID            string                `json:"user_id"`
+	Username          string                `json:"username"`
+	TimeToFirstReview latencyPercentilesDTO `json:"time_to_first_review"`
+	TimeToMerge       latencyPercentilesDTO `json:"time_to_merge"`
+}
+
+type latencyResponse struct {
+	Teams     []teamLatencyDTO     `json:"teams"`
+	Reviewers []reviewerLatencyDTO `json:"reviewers"`
+}
+
+func latencyPercentilesToDTO(p domain.LatencyPercentiles) latencyPercentilesDTO {
+	return latencyPercentilesDTO{
+		Count:      p.Count,
+		P50Seconds: p.P50Seconds,
+		P90Seconds: p.P90Seconds,
+	}
+}
+
+func (h *Handler) GetLatencyStats(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	from, to, err := parseTimeWindow(q)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	report, err := h.statsService.GetLatency(r.Context(), domain.LatencyFilter{
+		From:     from,
+		To:       to,
+		TeamName: domain.TeamName(q.Get("team_name")),
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	resp := latencyResponse{
+		Teams:     make([]teamLatencyDTO, 0, len(report.Teams)),
+		Reviewers: make([]reviewerLatencyDTO, 0, len(report.Reviewers)),
+	}
+
+	for _, t := range report.Teams {
+		resp.Teams = append(resp.Teams, teamLatencyDTO{
+			TeamName:          string(t.TeamName),
+			TimeToFirstReview: latencyPercentilesToDTO(t.TimeToFirstReview),
+			TimeToMerge:       latencyPercentilesToDTO(t.TimeToMerge),
+		})
+	}
+
+	for _, rv := range report.Reviewers {
+		resp.Reviewers = append(resp.Reviewers, reviewerLatencyDTO{
+			UserID:            string(rv.UserID),
+			Username:          rv.Username,
+			TimeToFirstReview: latencyPercentilesToDTO(rv.TimeToFirstReview),
+			TimeToMerge:       latencyPercentilesToDTO(rv.TimeToMerge),
+		})
+	}
+
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+type memberFairnessDTO struct {
+	UserID        string  `json:"user_id"`
+	Username      string  `json:"username"`
+	ActiveDays    float64 `json:"active_days"`
+	AssignedCount int64   `json:"assigned_count"`
+	ExpectedShare float64 `json:"expected_share"`
+	ActualShare   float64 `json:"actual_share"`
+}
+
+type teamFairnessDTO struct {
+	TeamName         string              `json:"team_name"`
+	TotalAssignments int64               `json:"total_assignments"`
+	Gini             float64             `json:"gini"`
+	Spread           float64             `json:"spread"`
+	Members          []memberFairnessDTO `json:"members"`
+}
+
+func (h *Handler) GetFairnessStats(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	from, to, err := parseTimeWindow(q)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	teams, err := h.statsService.GetFairness(r.Context(), domain.FairnessFilter{
+		From:     from,
+		To:       to,
+		TeamName: domain.TeamName(q.Get("team_name")),
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	resp := struct {
+		Teams []teamFairnessDTO `json:"teams"`
+	}{
+		Teams: make([]teamFairnessDTO, 0, len(teams)),
+	}
+
+	for _, t := range teams {
+		dto := teamFairnessDTO{
+			TeamName:         string(t.TeamName),
+			TotalAssignments: t.TotalAssignments,
+			Gini:             t.Gini,
+			Spread:           t.Spread,
+			Members:          make([]memberFairnessDTO, 0, len(t.Members)),
+		}
+		for _, m := range t.Members {
+			dto.Members = append(dto.Members, memberFairnessDTO{
+				UserID:        string(m.UserID),
+				Username:      m.Username,
+				ActiveDays:    m.ActiveDays,
+				AssignedCount: m.AssignedCount,
+				ExpectedShare: m.ExpectedShare,
+				ActualShare:   m.ActualShare,
+			})
+		}
+		resp.Teams = append(resp.Teams, dto)
+	}
+
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+func parseTimeWindow(q url.Values) (from, to *time.Time, err error) {
+	if from, err = parseTimeParam(q, "from"); err != nil {
+		return nil, nil, err
+	}
+	if to, err = parseTimeParam(q, "to"); err != nil {
+		return nil, nil, err
+	}
+	return from, to, nil
+}
+
+func parseTimeParam(q url.Values, name string) (*time.Time, error) {
+	v := q.Get(name)
+	if v == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, domain.NewValidationError(name, "must be an RFC 3339 timestamp")
+	}
+	return &t, nil
+}
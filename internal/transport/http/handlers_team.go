@@ -12,9 +12,15 @@ type teamAddResponse struct {
 	Team teamDTO `json:"team"`
 }
 
+type setReviewerStrategyRequest struct {
+	TeamName         string `json:"team_name"`
+	ReviewerStrategy string `json:"reviewer_strategy"`
+}
+
 type bulkDeactivateRequest struct {
 	TeamName string   `json:"team_name"`
 	UserIDs  []string `json:"user_ids"`
+	ActorID  string   `json:"actor_id"`
 }
 
 type bulkNotReassignedDTO struct {
@@ -23,11 +29,51 @@ type bulkNotReassignedDTO struct {
 	Reason        string `json:"reason"`
 }
 
+type bulkFallbackDTO struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldUserID     string `json:"old_user_id"`
+	NewUserID     string `json:"new_user_id"`
+	TeamName      string `json:"team_name"`
+}
+
 type bulkDeactivateResponse struct {
 	TeamName           string                 `json:"team_name"`
 	DeactivatedUserIDs []string               `json:"deactivated_user_ids"`
 	ReassignedCount    int                    `json:"reassigned_count"`
 	NotReassigned      []bulkNotReassignedDTO `json:"not_reassigned"`
+	Fallbacks          []bulkFallbackDTO      `json:"fallbacks"`
+}
+
+type fallbackTeamsDTO struct {
+	TeamName      string   `json:"team_name"`
+	FallbackTeams []string `json:"fallback_teams"`
+}
+
+type codeownersDTO struct {
+	TeamName  string     `json:"team_name"`
+	Mode      string     `json:"mode"`
+	Content   string     `json:"content"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+func codeownersToDTO(c domain.Codeowners) codeownersDTO {
+	return codeownersDTO{
+		TeamName:  string(c.TeamName),
+		Mode:      string(c.Mode),
+		Content:   c.Content,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func fallbackTeamsToDTO(team domain.TeamName, fallbacks []domain.TeamName) fallbackTeamsDTO {
+	dto := fallbackTeamsDTO{
+		TeamName:      string(team),
+		FallbackTeams: make([]string, 0, len(fallbacks)),
+	}
+	for _, f := range fallbacks {
+		dto.FallbackTeams = append(dto.FallbackTeams, string(f))
+	}
+	return dto
 }
 
 func (h *Handler) TeamAdd(w http.ResponseWriter, r *http.Request) {
@@ -68,6 +114,127 @@ func (h *Handler) TeamGet(w http.ResponseWriter, r *http.Request) {
 	h.writeJSON(w, http.StatusOK, teamToDTO(team))
 }
 
+func (h *Handler) TeamSetReviewerStrategy(w http.ResponseWriter, r *http.Request) {
+	var req setReviewerStrategyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	team, err := h.teamService.SetReviewerStrategy(r.Context(), domain.TeamName(req.TeamName), domain.ReviewerStrategy(req.ReviewerStrategy))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, teamAddResponse{Team: teamToDTO(team)})
+}
+
+func (h *Handler) TeamGetSettings(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		h.writeError(w, domain.NewValidationError("team_name", "must not be empty"))
+		return
+	}
+
+	settings, err := h.teamService.GetSettings(r.Context(), domain.TeamName(teamName))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, teamSettingsToDTO(settings))
+}
+
+func (h *Handler) TeamUpdateSettings(w http.ResponseWriter, r *http.Request) {
+	var req teamSettingsPatchDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	settings, err := h.teamService.UpdateSettings(r.Context(), domain.TeamName(req.TeamName), teamSettingsPatchFromDTO(req))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, teamSettingsToDTO(settings))
+}
+
+func (h *Handler) TeamGetFallbackTeams(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		h.writeError(w, domain.NewValidationError("team_name", "must not be empty"))
+		return
+	}
+
+	fallbacks, err := h.teamService.GetFallbackTeams(r.Context(), domain.TeamName(teamName))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, fallbackTeamsToDTO(domain.TeamName(teamName), fallbacks))
+}
+
+func (h *Handler) TeamSetFallbackTeams(w http.ResponseWriter, r *http.Request) {
+	var req fallbackTeamsDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	fallbacks := make([]domain.TeamName, 0, len(req.FallbackTeams))
+	for _, f := range req.FallbackTeams {
+		fallbacks = append(fallbacks, domain.TeamName(f))
+	}
+
+	fallbacks, err := h.teamService.SetFallbackTeams(r.Context(), domain.TeamName(req.TeamName), fallbacks)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, fallbackTeamsToDTO(domain.TeamName(req.TeamName), fallbacks))
+}
+
+func (h *Handler) TeamGetCodeowners(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		h.writeError(w, domain.NewValidationError("team_name", "must not be empty"))
+		return
+	}
+
+	co, err := h.teamService.GetCodeowners(r.Context(), domain.TeamName(teamName))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, codeownersToDTO(co))
+}
+
+func (h *Handler) TeamSetCodeowners(w http.ResponseWriter, r *http.Request) {
+	var req codeownersDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	co, err := h.teamService.SetCodeowners(r.Context(), domain.Codeowners{
+		TeamName: domain.TeamName(req.TeamName),
+		Content:  req.Content,
+		Mode:     domain.CodeownersMode(req.Mode),
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, codeownersToDTO(co))
+}
+
 func (h *Handler) BulkDeactivateTeamMembers(w http.ResponseWriter, r *http.Request) {
 
 	start := time.Now()
@@ -103,7 +270,7 @@ func (h *Handler) BulkDeactivateTeamMembers(w http.ResponseWriter, r *http.Reque
 		userIDs = append(userIDs, domain.UserID(id))
 	}
 
-	result, err := h.prService.BulkDeactivateAndReassign(ctx, domain.TeamName(req.TeamName), userIDs)
+	result, err := h.prService.BulkDeactivateAndReassign(ctx, domain.TeamName(req.TeamName), userIDs, domain.UserID(req.ActorID))
 	if err != nil {
 		h.writeError(w, err)
 		return
@@ -114,6 +281,7 @@ func (h *Handler) BulkDeactivateTeamMembers(w http.ResponseWriter, r *http.Reque
 		DeactivatedUserIDs: make([]string, 0, len(result.DeactivatedUserIDs)),
 		ReassignedCount:    result.ReassignedCount,
 		NotReassigned:      make([]bulkNotReassignedDTO, 0, len(result.NotReassigned)),
+		Fallbacks:          make([]bulkFallbackDTO, 0, len(result.Fallbacks)),
 	}
 
 	for _, id := range result.DeactivatedUserIDs {
@@ -128,5 +296,14 @@ func (h *Handler) BulkDeactivateTeamMembers(w http.ResponseWriter, r *http.Reque
 		})
 	}
 
+	for _, f := range result.Fallbacks {
+		resp.Fallbacks = append(resp.Fallbacks, bulkFallbackDTO{
+			PullRequestID: string(f.PullRequestID),
+			OldUserID:     string(f.OldUserID),
+			NewUserID:     string(f.NewUserID),
+			TeamName:      string(f.TeamName),
+		})
+	}
+
 	h.writeJSON(w, http.StatusOK, resp)
 }
@@ -3,6 +3,7 @@ package http
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/freeholder/pr-reviewer-service/internal/domain"
 )
@@ -12,6 +13,53 @@ type setIsActiveRequest struct {
 	IsActive bool   `json:"is_active"`
 }
 
+type setSlackHandleRequest struct {
+	UserID      string `json:"user_id"`
+	SlackHandle string `json:"slack_handle"`
+}
+
+type setEmailRequest struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+type setMaxOpenReviewsRequest struct {
+	UserID         string `json:"user_id"`
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+}
+
+type setSkillsRequest struct {
+	UserID string   `json:"user_id"`
+	Skills []string `json:"skills"`
+}
+
+type addAbsenceRequest struct {
+	UserID          string    `json:"user_id"`
+	StartsAt        time.Time `json:"starts_at"`
+	EndsAt          time.Time `json:"ends_at"`
+	Reason          string    `json:"reason"`
+	ReassignReviews bool      `json:"reassign_reviews"`
+}
+
+type absenceIDRequest struct {
+	ID int64 `json:"id"`
+}
+
+type absenceDTO struct {
+	ID              int64      `json:"id"`
+	UserID          string     `json:"user_id"`
+	StartsAt        time.Time  `json:"starts_at"`
+	EndsAt          time.Time  `json:"ends_at"`
+	Reason          string     `json:"reason,omitempty"`
+	ReassignReviews bool       `json:"reassign_reviews"`
+	ReassignedAt    *time.Time `json:"reassigned_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type absenceResponse struct {
+	Absence absenceDTO `json:"absence"`
+}
+
 type userResponse struct {
 	User userDTO `json:"user"`
 }
@@ -42,6 +90,70 @@ func (h *Handler) UserSetIsActive(w http.ResponseWriter, r *http.Request) {
 	h.writeJSON(w, http.StatusOK, userResponse{User: userToDTO(user)})
 }
 
+func (h *Handler) UserSetSlackHandle(w http.ResponseWriter, r *http.Request) {
+	var req setSlackHandleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	user, err := h.userService.SetSlackHandle(r.Context(), domain.UserID(req.UserID), req.SlackHandle)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, userResponse{User: userToDTO(user)})
+}
+
+func (h *Handler) UserSetEmail(w http.ResponseWriter, r *http.Request) {
+	var req setEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	user, err := h.userService.SetEmail(r.Context(), domain.UserID(req.UserID), req.Email)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, userResponse{User: userToDTO(user)})
+}
+
+func (h *Handler) UserSetMaxOpenReviews(w http.ResponseWriter, r *http.Request) {
+	var req setMaxOpenReviewsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	user, err := h.userService.SetMaxOpenReviews(r.Context(), domain.UserID(req.UserID), req.MaxOpenReviews)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, userResponse{User: userToDTO(user)})
+}
+
+func (h *Handler) UserSetSkills(w http.ResponseWriter, r *http.Request) {
+	var req setSkillsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	user, err := h.userService.SetSkills(r.Context(), domain.UserID(req.UserID), req.Skills)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, userResponse{User: userToDTO(user)})
+}
+
 func (h *Handler) UserGetReview(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("user_id")
 	if userID == "" {
@@ -66,3 +178,76 @@ func (h *Handler) UserGetReview(w http.ResponseWriter, r *http.Request) {
 
 	h.writeJSON(w, http.StatusOK, resp)
 }
+
+func absenceToDTO(a domain.Absence) absenceDTO {
+	return absenceDTO{
+		ID:              a.ID,
+		UserID:          string(a.UserID),
+		StartsAt:        a.StartsAt,
+		EndsAt:          a.EndsAt,
+		Reason:          a.Reason,
+		ReassignReviews: a.ReassignReviews,
+		ReassignedAt:    a.ReassignedAt,
+		CreatedAt:       a.CreatedAt,
+	}
+}
+
+func (h *Handler) UserAddAbsence(w http.ResponseWriter, r *http.Request) {
+	var req addAbsenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	absence, err := h.absenceService.Create(r.Context(), domain.Absence{
+		UserID:          domain.UserID(req.UserID),
+		StartsAt:        req.StartsAt,
+		EndsAt:          req.EndsAt,
+		Reason:          req.Reason,
+		ReassignReviews: req.ReassignReviews,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, absenceResponse{Absence: absenceToDTO(absence)})
+}
+
+func (h *Handler) UserListAbsences(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+
+	absences, err := h.absenceService.List(r.Context(), domain.UserID(userID))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	resp := struct {
+		UserID   string       `json:"user_id"`
+		Absences []absenceDTO `json:"absences"`
+	}{
+		UserID:   userID,
+		Absences: make([]absenceDTO, 0, len(absences)),
+	}
+	for _, a := range absences {
+		resp.Absences = append(resp.Absences, absenceToDTO(a))
+	}
+
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) UserDeleteAbsence(w http.ResponseWriter, r *http.Request) {
+	var req absenceIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	if err := h.absenceService.Delete(r.Context(), req.ID); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
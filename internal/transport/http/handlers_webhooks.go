@@ -0,0 +1,260 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+type webhookSubscriptionDTO struct {
+	ID         int64     `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type webhookDeliveryDTO struct {
+	ID             int64           `json:"id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"nextAttemptAt,omitempty"`
+	LastAttemptAt  *time.Time      `json:"lastAttemptAt,omitempty"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty"`
+}
+
+type createSubscriptionRequest struct {
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types"`
+	IsActive   *bool    `json:"is_active"`
+}
+
+type updateSubscriptionRequest struct {
+	ID         int64     `json:"id"`
+	URL        *string   `json:"url"`
+	Secret     *string   `json:"secret"`
+	EventTypes *[]string `json:"event_types"`
+	IsActive   *bool     `json:"is_active"`
+}
+
+type subscriptionIDRequest struct {
+	ID int64 `json:"id"`
+}
+
+type redeliverRequest struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+type subscriptionResponse struct {
+	Subscription webhookSubscriptionDTO `json:"subscription"`
+}
+
+type deliveryResponse struct {
+	Delivery webhookDeliveryDTO `json:"delivery"`
+}
+
+func subscriptionToDTO(s domain.WebhookSubscription) webhookSubscriptionDTO {
+	dto := webhookSubscriptionDTO{
+		ID:         s.ID,
+		URL:        s.URL,
+		EventTypes: make([]string, 0, len(s.EventTypes)),
+		IsActive:   s.IsActive,
+		CreatedAt:  s.CreatedAt,
+	}
+	for _, t := range s.EventTypes {
+		dto.EventTypes = append(dto.EventTypes, string(t))
+	}
+	return dto
+}
+
+func deliveryToDTO(d domain.WebhookDelivery) webhookDeliveryDTO {
+	dto := webhookDeliveryDTO{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventType:      string(d.EventType),
+		Payload:        json.RawMessage(d.Payload),
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		LastAttemptAt:  d.LastAttemptAt,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+	if d.Status == domain.WebhookDeliveryPending {
+		t := d.NextAttemptAt
+		dto.NextAttemptAt = &t
+	}
+	return dto
+}
+
+func eventTypesFromDTO(types []string) []domain.EventType {
+	result := make([]domain.EventType, 0, len(types))
+	for _, t := range types {
+		result = append(result, domain.EventType(t))
+	}
+	return result
+}
+
+func parseIDParam(value, field string) (int64, error) {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, domain.NewValidationError(field, "must be a positive integer")
+	}
+	return id, nil
+}
+
+func (h *Handler) WebhookCreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var req createSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	sub := domain.WebhookSubscription{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: eventTypesFromDTO(req.EventTypes),
+		IsActive:   req.IsActive == nil || *req.IsActive,
+	}
+
+	created, err := h.webhookService.CreateSubscription(r.Context(), sub)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, subscriptionResponse{Subscription: subscriptionToDTO(created)})
+}
+
+func (h *Handler) WebhookListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subs, err := h.webhookService.ListSubscriptions(r.Context())
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	resp := struct {
+		Subscriptions []webhookSubscriptionDTO `json:"subscriptions"`
+	}{
+		Subscriptions: make([]webhookSubscriptionDTO, 0, len(subs)),
+	}
+	for _, s := range subs {
+		resp.Subscriptions = append(resp.Subscriptions, subscriptionToDTO(s))
+	}
+
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) WebhookGetSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := parseIDParam(r.URL.Query().Get("id"), "id")
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	sub, err := h.webhookService.GetSubscription(r.Context(), id)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, subscriptionResponse{Subscription: subscriptionToDTO(sub)})
+}
+
+func (h *Handler) WebhookUpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	var req updateSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	patch := domain.WebhookSubscriptionPatch{
+		URL:      req.URL,
+		Secret:   req.Secret,
+		IsActive: req.IsActive,
+	}
+	if req.EventTypes != nil {
+		types := eventTypesFromDTO(*req.EventTypes)
+		patch.EventTypes = &types
+	}
+
+	sub, err := h.webhookService.UpdateSubscription(r.Context(), req.ID, patch)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, subscriptionResponse{Subscription: subscriptionToDTO(sub)})
+}
+
+func (h *Handler) WebhookDeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	var req subscriptionIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(r.Context(), req.ID); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) WebhookListDeliveries(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	id, err := parseIDParam(q.Get("subscription_id"), "subscription_id")
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	status := domain.WebhookDeliveryStatus(strings.ToUpper(q.Get("status")))
+
+	deliveries, err := h.webhookService.ListDeliveries(r.Context(), id, status)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	resp := struct {
+		Deliveries []webhookDeliveryDTO `json:"deliveries"`
+	}{
+		Deliveries: make([]webhookDeliveryDTO, 0, len(deliveries)),
+	}
+	for _, d := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, deliveryToDTO(d))
+	}
+
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) WebhookRedeliver(w http.ResponseWriter, r *http.Request) {
+	var req redeliverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, domain.NewValidationError("body", "invalid JSON body"))
+		return
+	}
+
+	d, err := h.webhookService.Redeliver(r.Context(), req.DeliveryID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusAccepted, deliveryResponse{Delivery: deliveryToDTO(d)})
+}
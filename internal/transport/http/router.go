@@ -18,21 +18,62 @@ func NewRouter(h *Handler) http.Handler {
 		r.Post("/add", h.TeamAdd)
 		r.Get("/get", h.TeamGet)
 		r.Post("/bulkDeactivate", h.BulkDeactivateTeamMembers)
+		r.Post("/setReviewerStrategy", h.TeamSetReviewerStrategy)
+		r.Get("/settings", h.TeamGetSettings)
+		r.Post("/settings", h.TeamUpdateSettings)
+		r.Get("/fallbackTeams", h.TeamGetFallbackTeams)
+		r.Post("/setFallbackTeams", h.TeamSetFallbackTeams)
+		r.Get("/codeowners", h.TeamGetCodeowners)
+		r.Post("/setCodeowners", h.TeamSetCodeowners)
 	})
 
 	r.Route("/users", func(r chi.Router) {
 		r.Post("/setIsActive", h.UserSetIsActive)
+		r.Post("/setSlackHandle", h.UserSetSlackHandle)
+		r.Post("/setEmail", h.UserSetEmail)
+		r.Post("/setMaxOpenReviews", h.UserSetMaxOpenReviews)
+		r.Post("/setSkills", h.UserSetSkills)
 		r.Get("/getReview", h.UserGetReview)
+		r.Post("/addAbsence", h.UserAddAbsence)
+		r.Get("/absences", h.UserListAbsences)
+		r.Post("/deleteAbsence", h.UserDeleteAbsence)
 	})
 
 	r.Route("/pullRequest", func(r chi.Router) {
 		r.Post("/create", h.PRCreate)
+		r.Post("/suggest", h.PRSuggest)
 		r.Post("/merge", h.PRMerge)
 		r.Post("/reassign", h.PRReassign)
+		r.Post("/review", h.PRReview)
+		r.Post("/close", h.PRClose)
+		r.Post("/reopen", h.PRReopen)
+		r.Post("/ready", h.PRReady)
+		r.Get("/assignments", h.PRAssignments)
+		r.Get("/history", h.PRHistory)
+		r.Get("/backlog", h.PRBacklog)
+	})
+
+	r.Route("/webhooks", func(r chi.Router) {
+		r.Post("/subscriptions", h.WebhookCreateSubscription)
+		r.Get("/subscriptions", h.WebhookListSubscriptions)
+		r.Get("/subscriptions/get", h.WebhookGetSubscription)
+		r.Post("/subscriptions/update", h.WebhookUpdateSubscription)
+		r.Post("/subscriptions/delete", h.WebhookDeleteSubscription)
+		r.Get("/deliveries", h.WebhookListDeliveries)
+		r.Post("/deliveries/redeliver", h.WebhookRedeliver)
+	})
+
+	r.Route("/integrations", func(r chi.Router) {
+		r.Post("/userMappings", h.SetUserMappings)
+		r.Get("/userMappings", h.GetUserMappings)
+		r.Post("/github/webhook", h.GitHubWebhook)
+		r.Post("/gitlab/webhook", h.GitLabWebhook)
 	})
 
 	r.Get("/health", h.Health)
 	r.Get("/stats/reviewers", h.GetReviewerStats)
+	r.Get("/stats/latency", h.GetLatencyStats)
+	r.Get("/stats/fairness", h.GetFairnessStats)
 
 	return r
 }
@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/freeholder/pr-reviewer-service/internal/domain"
+)
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		secret string
+		body   string
+		want   string
+	}{
+		// RFC 4231, test case 2.
+		{"Jefe", "what do ya want for nothing?", "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"},
+		// GitHub webhook documentation example.
+		{"It's a Secret to Everybody", "Hello, World!", "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"},
+	}
+
+	for _, tt := range tests {
+		if got := Sign(tt.secret, []byte(tt.body)); got != tt.want {
+			t.Errorf("Sign(%q, %q) = %s, want %s", tt.secret, tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestSendSignsPayload(t *testing.T) {
+	payload := []byte(`{"event":"pr.merged"}`)
+
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != string(payload) {
+			t.Errorf("body = %s, want %s", body, payload)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	status, err := NewSender(time.Second).Send(context.Background(), domain.WebhookDispatch{
+		Delivery: domain.WebhookDelivery{ID: 42, EventType: domain.EventPRMerged, Payload: payload},
+		URL:      srv.URL,
+		Secret:   "s3cret",
+	})
+	if err != nil || status != http.StatusAccepted {
+		t.Fatalf("Send = %d, %v; want 202, nil", status, err)
+	}
+	if got.Get(HeaderSignature) != Sign("s3cret", payload) {
+		t.Errorf("%s = %q, want %q", HeaderSignature, got.Get(HeaderSignature), Sign("s3cret", payload))
+	}
+	if got.Get(HeaderEvent) != "pr.merged" || got.Get(HeaderDelivery) != "42" {
+		t.Errorf("event headers = %q, %q", got.Get(HeaderEvent), got.Get(HeaderDelivery))
+	}
+}
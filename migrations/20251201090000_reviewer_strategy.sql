@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE teams
+    ADD COLUMN reviewer_strategy TEXT NOT NULL DEFAULT 'RANDOM'
+        CHECK (reviewer_strategy IN ('RANDOM', 'LEAST_LOADED', 'ROUND_ROBIN', 'WEIGHTED_RANDOM'));
+
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN assigned_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX idx_pr_reviewers_reviewer_assigned_at
+    ON pull_request_reviewers(reviewer_id, assigned_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_pr_reviewers_reviewer_assigned_at;
+
+ALTER TABLE pull_request_reviewers
+    DROP COLUMN IF EXISTS assigned_at;
+
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS reviewer_strategy;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE teams
+    ALTER COLUMN reviewer_strategy SET DEFAULT 'LEAST_LOADED';
+
+-- RANDOM only ever came from the column default of the previous migration,
+-- so teams still on it move to the new default.
+UPDATE teams SET reviewer_strategy = 'LEAST_LOADED' WHERE reviewer_strategy = 'RANDOM';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE teams
+    ALTER COLUMN reviewer_strategy SET DEFAULT 'RANDOM';
+-- +goose StatementEnd
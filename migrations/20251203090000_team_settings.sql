@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE team_settings (
+    team_name     TEXT PRIMARY KEY REFERENCES teams(team_name) ON DELETE CASCADE,
+    min_reviewers INT NOT NULL DEFAULT 0 CHECK (min_reviewers >= 0),
+    max_reviewers INT NOT NULL DEFAULT 2 CHECK (max_reviewers >= 1),
+    CHECK (min_reviewers <= max_reviewers)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS team_settings;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN state TEXT NOT NULL DEFAULT 'PENDING'
+        CHECK (state IN ('PENDING', 'APPROVED', 'CHANGES_REQUESTED')),
+    ADD COLUMN reviewed_at TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pull_request_reviewers
+    DROP COLUMN IF EXISTS reviewed_at,
+    DROP COLUMN IF EXISTS state;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE team_settings
+    ADD COLUMN required_approvals INT NOT NULL DEFAULT 0 CHECK (required_approvals >= 0),
+    ADD COLUMN block_on_changes_requested BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN require_all_approved BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE team_settings
+    DROP COLUMN IF EXISTS require_all_approved,
+    DROP COLUMN IF EXISTS block_on_changes_requested,
+    DROP COLUMN IF EXISTS required_approvals;
+-- +goose StatementEnd
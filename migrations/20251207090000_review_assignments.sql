@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE review_assignments (
+    id              BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    reviewer_id     TEXT NOT NULL REFERENCES users(user_id),
+    assigned_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    unassigned_at   TIMESTAMPTZ,
+    reason          TEXT NOT NULL CHECK (reason IN ('initial', 'reassign', 'bulk_deactivate', 'manual')),
+    actor           TEXT
+);
+
+CREATE INDEX idx_review_assignments_pr
+    ON review_assignments(pull_request_id, assigned_at);
+
+CREATE INDEX idx_review_assignments_reviewer
+    ON review_assignments(reviewer_id, assigned_at);
+
+INSERT INTO review_assignments (pull_request_id, reviewer_id, assigned_at, reason)
+SELECT pull_request_id, reviewer_id, assigned_at, 'initial'
+FROM pull_request_reviewers;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_review_assignments_reviewer;
+DROP INDEX IF EXISTS idx_review_assignments_pr;
+DROP TABLE IF EXISTS review_assignments;
+-- +goose StatementEnd
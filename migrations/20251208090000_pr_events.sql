@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE pr_events (
+    id              BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    event_type      TEXT NOT NULL,
+    actor           TEXT,
+    reviewer_id     TEXT,
+    old_reviewer_id TEXT,
+    details         TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_pr_events_pr
+    ON pr_events(pull_request_id, created_at, id);
+
+INSERT INTO pr_events (pull_request_id, event_type, actor, created_at)
+SELECT pull_request_id, 'created', author_id, created_at
+FROM pull_requests;
+
+INSERT INTO pr_events (pull_request_id, event_type, reviewer_id, actor, created_at)
+SELECT pull_request_id, 'reviewer_assigned', reviewer_id, actor, assigned_at
+FROM review_assignments;
+
+INSERT INTO pr_events (pull_request_id, event_type, created_at)
+SELECT pull_request_id, 'merged', merged_at
+FROM pull_requests
+WHERE merged_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_pr_events_pr;
+DROP TABLE IF EXISTS pr_events;
+-- +goose StatementEnd
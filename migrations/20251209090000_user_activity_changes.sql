@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_activity_changes (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    is_active  BOOLEAN NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_user_activity_changes_user
+    ON user_activity_changes(user_id, changed_at);
+
+-- History before this migration is unknown: treat the current state as
+-- having been in effect from the start.
+INSERT INTO user_activity_changes (user_id, is_active, changed_at)
+SELECT user_id, is_active, TIMESTAMPTZ 'epoch'
+FROM users;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_user_activity_changes_user;
+DROP TABLE IF EXISTS user_activity_changes;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE external_user_mappings (
+    provider TEXT NOT NULL,
+    login    TEXT NOT NULL,
+    user_id  TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    PRIMARY KEY (provider, login)
+);
+
+CREATE INDEX idx_external_user_mappings_user
+    ON external_user_mappings(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_external_user_mappings_user;
+DROP TABLE IF EXISTS external_user_mappings;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE outbox (
+    id              BIGSERIAL PRIMARY KEY,
+    event_type      TEXT NOT NULL,
+    payload         JSONB NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    attempts        INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error      TEXT,
+    dispatched_at   TIMESTAMPTZ
+);
+
+CREATE INDEX idx_outbox_pending
+    ON outbox(next_attempt_at)
+    WHERE dispatched_at IS NULL;
+
+ALTER TABLE webhook_deliveries
+    ADD COLUMN event_id BIGINT;
+
+CREATE UNIQUE INDEX idx_webhook_deliveries_event
+    ON webhook_deliveries(subscription_id, event_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_webhook_deliveries_event;
+ALTER TABLE webhook_deliveries
+    DROP COLUMN IF EXISTS event_id;
+
+DROP INDEX IF EXISTS idx_outbox_pending;
+DROP TABLE IF EXISTS outbox;
+-- +goose StatementEnd
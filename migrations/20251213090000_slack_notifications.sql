@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN slack_handle TEXT;
+
+ALTER TABLE team_settings
+    ADD COLUMN slack_webhook_url TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE team_settings
+    DROP COLUMN IF EXISTS slack_webhook_url;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS slack_handle;
+-- +goose StatementEnd
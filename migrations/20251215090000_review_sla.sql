@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE team_settings
+    ADD COLUMN reminder_after_hours INT NOT NULL DEFAULT 24 CHECK (reminder_after_hours >= 0),
+    ADD COLUMN escalate_after_hours INT NOT NULL DEFAULT 72 CHECK (escalate_after_hours >= 0);
+
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN reminded_at TIMESTAMPTZ,
+    ADD COLUMN escalated_at TIMESTAMPTZ;
+
+ALTER TABLE review_assignments
+    DROP CONSTRAINT IF EXISTS review_assignments_reason_check;
+
+ALTER TABLE review_assignments
+    ADD CONSTRAINT review_assignments_reason_check
+        CHECK (reason IN ('initial', 'reassign', 'bulk_deactivate', 'manual', 'sla_escalation'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE review_assignments
+    DROP CONSTRAINT IF EXISTS review_assignments_reason_check;
+
+UPDATE review_assignments SET reason = 'reassign' WHERE reason = 'sla_escalation';
+
+ALTER TABLE review_assignments
+    ADD CONSTRAINT review_assignments_reason_check
+        CHECK (reason IN ('initial', 'reassign', 'bulk_deactivate', 'manual'));
+
+ALTER TABLE pull_request_reviewers
+    DROP COLUMN IF EXISTS escalated_at,
+    DROP COLUMN IF EXISTS reminded_at;
+
+ALTER TABLE team_settings
+    DROP COLUMN IF EXISTS escalate_after_hours,
+    DROP COLUMN IF EXISTS reminder_after_hours;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_absences (
+    id               BIGSERIAL PRIMARY KEY,
+    user_id          TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    starts_at        TIMESTAMPTZ NOT NULL,
+    ends_at          TIMESTAMPTZ NOT NULL,
+    reason           TEXT,
+    reassign_reviews BOOLEAN NOT NULL DEFAULT FALSE,
+    reassigned_at    TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_user_absences_user
+    ON user_absences(user_id, starts_at, ends_at);
+
+CREATE INDEX idx_user_absences_pending_reassign
+    ON user_absences(starts_at)
+    WHERE reassign_reviews AND reassigned_at IS NULL;
+
+ALTER TABLE review_assignments
+    DROP CONSTRAINT IF EXISTS review_assignments_reason_check;
+
+ALTER TABLE review_assignments
+    ADD CONSTRAINT review_assignments_reason_check
+        CHECK (reason IN ('initial', 'reassign', 'bulk_deactivate', 'manual', 'sla_escalation', 'absence'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE review_assignments
+    DROP CONSTRAINT IF EXISTS review_assignments_reason_check;
+
+UPDATE review_assignments SET reason = 'reassign' WHERE reason = 'absence';
+
+ALTER TABLE review_assignments
+    ADD CONSTRAINT review_assignments_reason_check
+        CHECK (reason IN ('initial', 'reassign', 'bulk_deactivate', 'manual', 'sla_escalation'));
+
+DROP INDEX IF EXISTS idx_user_absences_pending_reassign;
+DROP INDEX IF EXISTS idx_user_absences_user;
+DROP TABLE IF EXISTS user_absences;
+-- +goose StatementEnd
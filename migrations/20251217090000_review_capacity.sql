@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN max_open_reviews INT CHECK (max_open_reviews >= 0);
+
+ALTER TABLE team_settings
+    ADD COLUMN max_open_reviews INT NOT NULL DEFAULT 0 CHECK (max_open_reviews >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE team_settings
+    DROP COLUMN IF EXISTS max_open_reviews;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS max_open_reviews;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE assignment_backlog (
+    pull_request_id TEXT PRIMARY KEY REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    missing         INT NOT NULL CHECK (missing > 0),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_attempt_at TIMESTAMPTZ
+);
+
+ALTER TABLE review_assignments
+    DROP CONSTRAINT IF EXISTS review_assignments_reason_check;
+
+ALTER TABLE review_assignments
+    ADD CONSTRAINT review_assignments_reason_check
+        CHECK (reason IN ('initial', 'reassign', 'bulk_deactivate', 'manual', 'sla_escalation', 'absence', 'backlog'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE review_assignments
+    DROP CONSTRAINT IF EXISTS review_assignments_reason_check;
+
+UPDATE review_assignments SET reason = 'initial' WHERE reason = 'backlog';
+
+ALTER TABLE review_assignments
+    ADD CONSTRAINT review_assignments_reason_check
+        CHECK (reason IN ('initial', 'reassign', 'bulk_deactivate', 'manual', 'sla_escalation', 'absence'));
+
+DROP TABLE IF EXISTS assignment_backlog;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE team_fallbacks (
+    team_name     TEXT NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    fallback_team TEXT NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    position      INT NOT NULL,
+    PRIMARY KEY (team_name, fallback_team),
+    UNIQUE (team_name, position),
+    CHECK (team_name <> fallback_team)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS team_fallbacks;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE team_codeowners (
+    team_name  TEXT PRIMARY KEY REFERENCES teams(team_name) ON DELETE CASCADE,
+    content    TEXT NOT NULL,
+    mode       TEXT NOT NULL DEFAULT 'PREFER' CHECK (mode IN ('PREFER', 'REQUIRE')),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE pull_request_files (
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    path            TEXT NOT NULL,
+    PRIMARY KEY (pull_request_id, path)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS pull_request_files;
+DROP TABLE IF EXISTS team_codeowners;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN skills TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE pull_requests
+    ADD COLUMN labels TEXT[] NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS labels;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS skills;
+-- +goose StatementEnd
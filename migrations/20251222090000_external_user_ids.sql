@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE external_user_mappings ADD COLUMN external_id BIGINT;
+
+CREATE UNIQUE INDEX idx_external_user_mappings_external_id
+    ON external_user_mappings(provider, external_id)
+    WHERE external_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_external_user_mappings_external_id;
+ALTER TABLE external_user_mappings DROP COLUMN IF EXISTS external_id;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE outbox
+    ADD COLUMN delivered_sinks TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN dead_at         TIMESTAMPTZ;
+
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX idx_outbox_pending
+    ON outbox(next_attempt_at)
+    WHERE dispatched_at IS NULL AND dead_at IS NULL;
+
+CREATE INDEX idx_outbox_dispatched
+    ON outbox(dispatched_at)
+    WHERE dispatched_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_outbox_dispatched;
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX idx_outbox_pending
+    ON outbox(next_attempt_at)
+    WHERE dispatched_at IS NULL;
+
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS dead_at,
+    DROP COLUMN IF EXISTS delivered_sinks;
+-- +goose StatementEnd
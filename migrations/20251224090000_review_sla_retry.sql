@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN sla_retry_at TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pull_request_reviewers
+    DROP COLUMN IF EXISTS sla_retry_at;
+-- +goose StatementEnd
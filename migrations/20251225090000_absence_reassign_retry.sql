@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_absences
+    ADD COLUMN reassign_retry_at TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_absences
+    DROP COLUMN IF EXISTS reassign_retry_at;
+-- +goose StatementEnd